@@ -323,7 +323,12 @@ func messagesToBlockSeals(l []*entities.BlockSeal) ([]*flow.BlockSeal, error) {
 }
 
 func eventToMessage(e flow.Event) (*entities.Event, error) {
-	payload, err := cadenceValueToMessage(e.Value)
+	value, err := e.DecodeValue()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := cadenceValueToMessage(value)
 	if err != nil {
 		return nil, err
 	}
@@ -337,7 +342,18 @@ func eventToMessage(e flow.Event) (*entities.Event, error) {
 	}, nil
 }
 
-func messageToEvent(m *entities.Event, options []jsoncdc.Option) (flow.Event, error) {
+// messageToEvent converts an event message, decoding its payload unless lazy is true.
+func messageToEvent(m *entities.Event, options []jsoncdc.Option, lazy bool) (flow.Event, error) {
+	if lazy {
+		return flow.Event{
+			Type:             m.GetType(),
+			TransactionID:    flow.HashToID(m.GetTransactionId()),
+			TransactionIndex: int(m.GetTransactionIndex()),
+			EventIndex:       int(m.GetEventIndex()),
+			Payload:          m.Payload,
+		}, nil
+	}
+
 	value, err := messageToCadenceValue(m.GetPayload(), options)
 	if err != nil {
 		return flow.Event{}, err
@@ -505,12 +521,12 @@ func transactionResultToMessage(result flow.TransactionResult) (*access.Transact
 	}, nil
 }
 
-func messageToTransactionResult(m *access.TransactionResultResponse, options []jsoncdc.Option) (flow.TransactionResult, error) {
+func messageToTransactionResult(m *access.TransactionResultResponse, options []jsoncdc.Option, lazyEvents bool) (flow.TransactionResult, error) {
 	eventMessages := m.GetEvents()
 
 	events := make([]flow.Event, len(eventMessages))
 	for i, eventMsg := range eventMessages {
-		event, err := messageToEvent(eventMsg, options)
+		event, err := messageToEvent(eventMsg, options, lazyEvents)
 		if err != nil {
 			return flow.TransactionResult{}, err
 		}
@@ -524,9 +540,9 @@ func messageToTransactionResult(m *access.TransactionResultResponse, options []j
 	if statusCode != 0 {
 		errorMsg := m.GetErrorMessage()
 		if errorMsg != "" {
-			err = errors.New(errorMsg)
+			err = flow.ParseTransactionExecutionError(errorMsg)
 		} else {
-			err = errors.New("transaction execution failed")
+			err = flow.ParseTransactionExecutionError("transaction execution failed")
 		}
 	}
 
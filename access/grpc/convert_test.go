@@ -199,7 +199,7 @@ func TestConvert_Event(t *testing.T) {
 	msg, err := eventToMessage(eventA)
 	require.NoError(t, err)
 
-	eventB, err := messageToEvent(msg, nil)
+	eventB, err := messageToEvent(msg, nil, false)
 	require.NoError(t, err)
 
 	// Force evaluation of type ID, which is cached in type.
@@ -209,6 +209,35 @@ func TestConvert_Event(t *testing.T) {
 	assert.Equal(t, eventA, eventB)
 }
 
+func TestConvert_LazyEvent(t *testing.T) {
+	eventA := test.EventGenerator().New()
+
+	msg, err := eventToMessage(eventA)
+	require.NoError(t, err)
+
+	eventB, err := messageToEvent(msg, nil, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, eventA.Type, eventB.Type)
+	assert.Equal(t, eventA.ID(), eventB.ID())
+	assert.Equal(t, eventA.Payload, eventB.Payload)
+	assert.Nil(t, eventB.Value.EventType)
+
+	lazyMsg, err := eventToMessage(eventB)
+	require.NoError(t, err)
+	assert.Equal(t, msg.Payload, lazyMsg.Payload)
+
+	value, err := eventB.DecodeValue()
+	require.NoError(t, err)
+
+	// Force evaluation of type ID, which is cached in type.
+	// Necessary for equality check below
+	_ = value.Type().ID()
+
+	assert.Equal(t, eventA.Value, value)
+	assert.Equal(t, eventA, eventB)
+}
+
 func TestConvert_Identifier(t *testing.T) {
 	idA := test.IdentifierGenerator().New()
 
@@ -265,7 +294,7 @@ func TestConvert_TransactionResult(t *testing.T) {
 
 	msg, err := transactionResultToMessage(resultA)
 
-	resultB, err := messageToTransactionResult(msg, nil)
+	resultB, err := messageToTransactionResult(msg, nil, false)
 	require.NoError(t, err)
 
 	// Force evaluation of type ID, which is cached in type.
@@ -44,6 +44,7 @@ type BaseClient struct {
 	rpcClient   RPCClient
 	close       func() error
 	jsonOptions []json.Option
+	lazyEvents  bool
 }
 
 // NewBaseClient creates a new gRPC handler for network communication.
@@ -74,6 +75,17 @@ func (c *BaseClient) SetJSONOptions(options []json.Option) {
 	c.jsonOptions = options
 }
 
+// SetLazyEventDecoding sets whether event payloads are decoded when events are fetched.
+//
+// If lazy is true, events are returned with their raw JSON-CDC payload only, and their Value
+// field stays zero. Event data should always be read with flow.Event.DecodeValue, which decodes
+// lazily fetched events on first access. DecodeValue does not know the options of this client:
+// it decodes with the default options unless it is passed the same options as given to
+// SetJSONOptions.
+func (c *BaseClient) SetLazyEventDecoding(lazy bool) {
+	c.lazyEvents = lazy
+}
+
 // Close closes the client connection.
 func (c *BaseClient) Close() error {
 	return c.close()
@@ -312,7 +324,7 @@ func (c *BaseClient) GetTransactionResult(
 		return nil, newRPCError(err)
 	}
 
-	result, err := messageToTransactionResult(res, c.jsonOptions)
+	result, err := messageToTransactionResult(res, c.jsonOptions, c.lazyEvents)
 	if err != nil {
 		return nil, newMessageToEntityError(entityTransactionResult, err)
 	}
@@ -338,7 +350,7 @@ func (c *BaseClient) GetTransactionResultsByBlockID(
 	unparsedResults := res.GetTransactionResults()
 	results := make([]*flow.TransactionResult, 0, len(unparsedResults))
 	for _, result := range unparsedResults {
-		parsed, err := messageToTransactionResult(result, c.jsonOptions)
+		parsed, err := messageToTransactionResult(result, c.jsonOptions, c.lazyEvents)
 		if err != nil {
 			return nil, newMessageToEntityError(entityTransactionResult, err)
 		}
@@ -512,7 +524,7 @@ func (c *BaseClient) GetEventsForHeightRange(
 		return nil, newRPCError(err)
 	}
 
-	return getEventsResult(res, c.jsonOptions)
+	return getEventsResult(res, c.jsonOptions, c.lazyEvents)
 }
 
 func (c *BaseClient) GetEventsForBlockIDs(
@@ -531,10 +543,10 @@ func (c *BaseClient) GetEventsForBlockIDs(
 		return nil, newRPCError(err)
 	}
 
-	return getEventsResult(res, c.jsonOptions)
+	return getEventsResult(res, c.jsonOptions, c.lazyEvents)
 }
 
-func getEventsResult(res *access.EventsResponse, options []json.Option) ([]flow.BlockEvents, error) {
+func getEventsResult(res *access.EventsResponse, options []json.Option, lazyEvents bool) ([]flow.BlockEvents, error) {
 	resultMessages := res.GetResults()
 
 	results := make([]flow.BlockEvents, len(resultMessages))
@@ -544,7 +556,7 @@ func getEventsResult(res *access.EventsResponse, options []json.Option) ([]flow.
 		events := make([]flow.Event, len(eventMessages))
 
 		for i, m := range eventMessages {
-			evt, err := messageToEvent(m, options)
+			evt, err := messageToEvent(m, options, lazyEvents)
 			if err != nil {
 				return nil, newMessageToEntityError(entityEvent, err)
 			}
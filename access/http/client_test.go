@@ -19,9 +19,12 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk"
@@ -30,6 +33,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func clientTest(
@@ -318,7 +322,7 @@ func TestBaseClient_GetTransactionResult(t *testing.T) {
 		expectedTx, err := toTransaction(&httpTx)
 		assert.NoError(t, err)
 
-		expectedTxRes, err := toTransactionResult(&httpTxRes, nil)
+		expectedTxRes, err := toTransactionResult(&httpTxRes, nil, false)
 		assert.NoError(t, err)
 
 		handler.
@@ -485,7 +489,7 @@ func TestBaseClient_GetEvents(t *testing.T) {
 
 	t.Run("Get For Height Range", clientTest(func(ctx context.Context, t *testing.T, handler *mockHandler, client *Client) {
 		httpEvents := blockEventsFlowFixture()
-		expectedEvents, err := toBlockEvents([]models.BlockEvents{httpEvents}, nil)
+		expectedEvents, err := toBlockEvents([]models.BlockEvents{httpEvents}, nil, false)
 		const eType = "A.Foo.Bar"
 		handler.
 			On(handlerName, mock.Anything, eType, "0", "5", []string(nil)).
@@ -498,7 +502,7 @@ func TestBaseClient_GetEvents(t *testing.T) {
 
 	t.Run("Get For Block IDs", clientTest(func(ctx context.Context, t *testing.T, handler *mockHandler, client *Client) {
 		httpEvents := blockEventsFlowFixture()
-		expectedEvents, err := toBlockEvents([]models.BlockEvents{httpEvents}, nil)
+		expectedEvents, err := toBlockEvents([]models.BlockEvents{httpEvents}, nil, false)
 		const eType = "A.Foo.Bar"
 		handler.
 			On(handlerName, mock.Anything, eType, "", "", []string{expectedEvents[0].BlockID.String()}).
@@ -552,3 +556,100 @@ func TestBaseClient_GetEvents(t *testing.T) {
 	}))
 
 }
+
+func newTestStream(t *testing.T, v interface{}) *jsonArrayStream {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	stream, err := newJSONArrayStream(ioutil.NopCloser(bytes.NewReader(b)))
+	require.NoError(t, err)
+
+	return stream
+}
+
+func TestBaseClient_IterateEvents(t *testing.T) {
+	const handlerName = "getEventsStream"
+	const eType = "A.Foo.Bar"
+
+	t.Run("For Height Range", func(t *testing.T) {
+		handler := &mockHandler{}
+		client := &BaseClient{handler: handler}
+		ctx := context.Background()
+
+		httpEvents := []models.BlockEvents{blockEventsFlowFixture(), blockEventsFlowFixture()}
+		expectedEvents, err := toBlockEvents(httpEvents, nil, false)
+		require.NoError(t, err)
+
+		handler.
+			On(handlerName, mock.Anything, eType, "0", "5", []string(nil)).
+			Return(newTestStream(t, httpEvents), nil)
+
+		it, err := client.IterateEventsForHeightRange(ctx, eType, HeightQuery{Start: 0, End: 5})
+		require.NoError(t, err)
+		defer it.Close()
+
+		var events []flow.BlockEvents
+		for it.Next() {
+			events = append(events, it.Value())
+		}
+		require.NoError(t, it.Err())
+		assert.Equal(t, expectedEvents, events)
+		handler.AssertExpectations(t)
+	})
+
+	t.Run("For Block IDs With Lazy Decoding", func(t *testing.T) {
+		handler := &mockHandler{}
+		client := &BaseClient{handler: handler}
+		client.SetLazyEventDecoding(true)
+		ctx := context.Background()
+
+		httpEvents := []models.BlockEvents{blockEventsFlowFixture()}
+		expectedEvents, err := toBlockEvents(httpEvents, nil, true)
+		require.NoError(t, err)
+
+		handler.
+			On(handlerName, mock.Anything, eType, "", "", []string{expectedEvents[0].BlockID.String()}).
+			Return(newTestStream(t, httpEvents), nil)
+
+		it, err := client.IterateEventsForBlockIDs(ctx, eType, []flow.Identifier{expectedEvents[0].BlockID})
+		require.NoError(t, err)
+		defer it.Close()
+
+		require.True(t, it.Next())
+		assert.Equal(t, expectedEvents[0], it.Value())
+		assert.False(t, it.Next())
+		require.NoError(t, it.Err())
+	})
+
+	t.Run("Invalid Range", func(t *testing.T) {
+		client := &BaseClient{handler: &mockHandler{}}
+
+		_, err := client.IterateEventsForHeightRange(context.Background(), eType, HeightQuery{Start: 5, End: 1})
+		assert.Error(t, err)
+	})
+}
+
+func TestBaseClient_IterateBlocks(t *testing.T) {
+	handler := &mockHandler{}
+	client := &BaseClient{handler: handler}
+
+	httpBlock := blockFlowFixture()
+	expectedBlock, err := toBlock(&httpBlock)
+	require.NoError(t, err)
+
+	handler.
+		On("getBlocksByHeightsStream", mock.Anything, "", "0", "5").
+		Return(newTestStream(t, []models.Block{httpBlock, httpBlock}), nil)
+
+	it, err := client.IterateBlocksByHeights(context.Background(), HeightQuery{Start: 0, End: 5})
+	require.NoError(t, err)
+	defer it.Close()
+
+	var blocks []*flow.Block
+	for it.Next() {
+		blocks = append(blocks, it.Value())
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []*flow.Block{expectedBlock, expectedBlock}, blocks)
+	handler.AssertExpectations(t)
+}
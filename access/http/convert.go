@@ -311,7 +311,8 @@ func toTransactionStatus(status *models.TransactionStatus) flow.TransactionStatu
 	}
 }
 
-func toEvents(events []models.Event, options []cadenceJSON.Option) ([]flow.Event, error) {
+// toEvents converts events, decoding their payloads unless lazy is true.
+func toEvents(events []models.Event, options []cadenceJSON.Option, lazy bool) ([]flow.Event, error) {
 	flowEvents := make([]flow.Event, len(events))
 	for i, e := range events {
 		payload, err := base64.StdEncoding.DecodeString(e.Payload)
@@ -319,6 +320,17 @@ func toEvents(events []models.Event, options []cadenceJSON.Option) ([]flow.Event
 			return nil, err
 		}
 
+		if lazy {
+			flowEvents[i] = flow.Event{
+				Type:             e.Type_,
+				TransactionID:    flow.HexToID(e.TransactionId),
+				TransactionIndex: mustToInt(e.TransactionIndex),
+				EventIndex:       mustToInt(e.EventIndex),
+				Payload:          payload,
+			}
+			continue
+		}
+
 		event, err := cadenceJSON.Decode(nil, payload, options...)
 		if err != nil {
 			return nil, err
@@ -336,33 +348,42 @@ func toEvents(events []models.Event, options []cadenceJSON.Option) ([]flow.Event
 	return flowEvents, nil
 }
 
-func toBlockEvents(blockEvents []models.BlockEvents, options []cadenceJSON.Option) ([]flow.BlockEvents, error) {
+func toBlockEvents(blockEvents []models.BlockEvents, options []cadenceJSON.Option, lazyEvents bool) ([]flow.BlockEvents, error) {
 	blocks := make([]flow.BlockEvents, len(blockEvents))
 	for i, block := range blockEvents {
-		events, err := toEvents(block.Events, options)
+		events, err := toBlockEvent(block, options, lazyEvents)
 		if err != nil {
 			return nil, err
 		}
 
-		blocks[i] = flow.BlockEvents{
-			BlockID:        flow.HexToID(block.BlockId),
-			Height:         mustToUint(block.BlockHeight),
-			BlockTimestamp: block.BlockTimestamp,
-			Events:         events,
-		}
+		blocks[i] = events
 	}
 	return blocks, nil
 }
 
-func toTransactionResult(txr *models.TransactionResult, options []cadenceJSON.Option) (*flow.TransactionResult, error) {
-	events, err := toEvents(txr.Events, options)
+func toBlockEvent(block models.BlockEvents, options []cadenceJSON.Option, lazyEvents bool) (flow.BlockEvents, error) {
+	events, err := toEvents(block.Events, options, lazyEvents)
+	if err != nil {
+		return flow.BlockEvents{}, err
+	}
+
+	return flow.BlockEvents{
+		BlockID:        flow.HexToID(block.BlockId),
+		Height:         mustToUint(block.BlockHeight),
+		BlockTimestamp: block.BlockTimestamp,
+		Events:         events,
+	}, nil
+}
+
+func toTransactionResult(txr *models.TransactionResult, options []cadenceJSON.Option, lazyEvents bool) (*flow.TransactionResult, error) {
+	events, err := toEvents(txr.Events, options, lazyEvents)
 	if err != nil {
 		return nil, err
 	}
 
 	var txErr error
 	if txr.ErrorMessage != "" {
-		txErr = fmt.Errorf(txr.ErrorMessage)
+		txErr = flow.ParseTransactionExecutionError(txr.ErrorMessage)
 	}
 
 	return &flow.TransactionResult{
@@ -28,6 +28,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_ConvertBlock(t *testing.T) {
@@ -103,7 +104,7 @@ func Test_ConvertTransaction(t *testing.T) {
 
 func Test_ConvertTransactionResult(t *testing.T) {
 	httpTxr := transactionResultFlowFixture()
-	txr, err := toTransactionResult(&httpTxr, nil)
+	txr, err := toTransactionResult(&httpTxr, nil, false)
 
 	assert.NoError(t, err)
 	assert.Equal(t, txr.Status, flow.TransactionStatusSealed)
@@ -116,6 +117,27 @@ func Test_ConvertTransactionResult(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%d", txr.Events[0].TransactionIndex), httpTxr.Events[0].TransactionIndex)
 }
 
+func Test_ConvertTransactionResultLazyEvents(t *testing.T) {
+	httpTxr := transactionResultFlowFixture()
+
+	eager, err := toTransactionResult(&httpTxr, nil, false)
+	require.NoError(t, err)
+
+	lazy, err := toTransactionResult(&httpTxr, nil, true)
+	require.NoError(t, err)
+
+	require.Len(t, lazy.Events, len(eager.Events))
+	event := lazy.Events[0]
+	assert.Equal(t, eager.Events[0].Type, event.Type)
+	assert.Equal(t, eager.Events[0].Payload, event.Payload)
+	assert.Nil(t, event.Value.EventType)
+
+	value, err := event.DecodeValue()
+	require.NoError(t, err)
+	assert.Equal(t, eager.Events[0].Value.String(), value.String())
+	assert.Equal(t, value, event.Value)
+}
+
 func Test_EncodeCadenceArgs(t *testing.T) {
 	v1, _ := cadence.NewValue("Hello")
 	v2, _ := cadence.NewValue("World")
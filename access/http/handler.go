@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -48,10 +49,14 @@ func (h HTTPError) Error() string {
 	return h.Message
 }
 
+// ErrResponseTooLarge is returned when a response body exceeds the maximum response size.
+var ErrResponseTooLarge = errors.New("response exceeds maximum size")
+
 type httpHandler struct {
-	client *http.Client
-	base   string
-	debug  bool
+	client          *http.Client
+	base            string
+	debug           bool
+	maxResponseSize int64
 }
 
 func newHandler(host string, debug bool) (*httpHandler, error) {
@@ -67,6 +72,11 @@ func newHandler(host string, debug bool) (*httpHandler, error) {
 	}, nil
 }
 
+// setMaxResponseSize sets the maximum size of a response body in bytes, zero meaning no limit.
+func (h *httpHandler) setMaxResponseSize(size int64) {
+	h.maxResponseSize = size
+}
+
 func (h *httpHandler) mustBuildURL(path string, opts ...queryOpts) *url.URL {
 	u, _ := url.ParseRequestURI(fmt.Sprintf("%s%s", h.base, path))
 
@@ -79,43 +89,110 @@ func (h *httpHandler) mustBuildURL(path string, opts ...queryOpts) *url.URL {
 	return u
 }
 
-func (h *httpHandler) get(_ context.Context, url *url.URL, model interface{}) error {
+// limitedReader reads from r and fails with ErrResponseTooLarge once more than
+// remaining bytes are read.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	// keep failing, as callers such as json.Decoder may retry after an error
+	if l.exceeded {
+		return 0, ErrResponseTooLarge
+	}
+
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		n = int(l.remaining)
+		l.remaining = 0
+		l.exceeded = true
+		return n, ErrResponseTooLarge
+	}
+
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// do sends the request and returns the response body, which the caller must close.
+//
+// The body is read as a stream, so it is never held in memory in full unless debugging is enabled.
+func (h *httpHandler) do(ctx context.Context, method string, url *url.URL, body []byte) (io.ReadCloser, error) {
 	if h.debug {
-		fmt.Printf("\n-> GET %s t=%d", url.String(), time.Now().Unix())
+		fmt.Printf("\n-> %s %s t=%d - %s", method, url.String(), time.Now().Unix(), string(body))
 	}
 
-	// todo use a .Do() method and use the context
-	res, err := h.client.Get(url.String())
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), bytes.NewReader(body))
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
-	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
+	res, err := h.client.Do(req)
 	if err != nil {
-		return err
+		return nil, errors.Wrap(err, fmt.Sprintf("HTTP %s %s failed", method, url.String()))
 	}
 
-	if res.StatusCode >= http.StatusBadRequest {
-		if h.debug {
-			fmt.Printf("\n<- FAILED GET %s t=%d status=%d - %s", url.String(), res.StatusCode, time.Now().Unix(), body)
+	var reader io.Reader = res.Body
+	if h.maxResponseSize > 0 {
+		reader = &limitedReader{r: reader, remaining: h.maxResponseSize}
+	}
+
+	if h.debug {
+		responseBody, err := ioutil.ReadAll(reader)
+		if err != nil {
+			res.Body.Close()
+			return nil, err
 		}
 
+		fmt.Printf("\n<- %s %s t=%d status=%d - %s", method, url.String(), time.Now().Unix(), res.StatusCode, responseBody)
+		reader = bytes.NewReader(responseBody)
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+
 		var httpErr HTTPError
-		err = json.Unmarshal(body, &httpErr)
+		err = json.NewDecoder(reader).Decode(&httpErr)
 		if err != nil {
-			return err
+			// the error response did not come from the access API, for example from a proxy
+			return nil, HTTPError{
+				Url:  url.String(),
+				Code: res.StatusCode,
+				Message: fmt.Sprintf(
+					"HTTP %s %s failed with status %d: invalid error response: %s",
+					method, url.String(), res.StatusCode, err,
+				),
+			}
 		}
 
 		httpErr.Url = url.String()
-		return httpErr
+		return nil, httpErr
 	}
 
-	if h.debug {
-		fmt.Printf("\n<- GET %s t=%d - %s", url.String(), time.Now().Unix(), body)
+	return readCloser{Reader: reader, Closer: res.Body}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (h *httpHandler) decode(ctx context.Context, method string, url *url.URL, body []byte, model interface{}) error {
+	responseBody, err := h.do(ctx, method, url, body)
+	if err != nil {
+		return err
 	}
+	defer responseBody.Close()
 
-	err = json.Unmarshal(body, &model)
+	err = json.NewDecoder(responseBody).Decode(model)
 	if err != nil {
 		return errors.Wrap(err, "JSON decoding failed")
 	}
@@ -123,51 +200,79 @@ func (h *httpHandler) get(_ context.Context, url *url.URL, model interface{}) er
 	return nil
 }
 
-func (h *httpHandler) post(_ context.Context, url *url.URL, body []byte, model interface{}) error {
-	if h.debug {
-		fmt.Printf("\n-> POST %s t=%d - %s", url.String(), time.Now().Unix(), string(body))
-	}
+func (h *httpHandler) get(ctx context.Context, url *url.URL, model interface{}) error {
+	return h.decode(ctx, http.MethodGet, url, nil, model)
+}
 
-	res, err := h.client.Post(
-		url.String(),
-		"application/json",
-		bytes.NewReader(body),
-	)
+func (h *httpHandler) post(ctx context.Context, url *url.URL, body []byte, model interface{}) error {
+	return h.decode(ctx, http.MethodPost, url, body, model)
+}
+
+// jsonArrayStream decodes the elements of a JSON array response one at a time.
+type jsonArrayStream struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
+	done    bool
+}
+
+// getStream requests a JSON array and returns a stream of its elements.
+func (h *httpHandler) getStream(ctx context.Context, url *url.URL) (*jsonArrayStream, error) {
+	body, err := h.do(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("HTTP POST %s failed", url.String()))
+		return nil, err
 	}
-	defer res.Body.Close()
 
-	responseBody, err := ioutil.ReadAll(res.Body)
+	return newJSONArrayStream(body)
+}
+
+// newJSONArrayStream reads the opening bracket of a JSON array from body and returns a stream of its elements.
+func newJSONArrayStream(body io.ReadCloser) (*jsonArrayStream, error) {
+	decoder := json.NewDecoder(body)
+
+	token, err := decoder.Token()
 	if err != nil {
-		return err
+		body.Close()
+		return nil, errors.Wrap(err, "JSON decoding failed")
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		body.Close()
+		return nil, fmt.Errorf("JSON decoding failed: expected array, got %v", token)
 	}
 
-	if res.StatusCode >= http.StatusBadRequest {
-		if h.debug {
-			fmt.Printf("\n<- POST FAILED %s, status=%d, response: %s", url.String(), res.StatusCode, responseBody)
-		}
+	return &jsonArrayStream{
+		body:    body,
+		decoder: decoder,
+	}, nil
+}
 
-		var httpErr HTTPError
-		err = json.Unmarshal(responseBody, &httpErr)
+// next decodes the next element into v and returns false once the end of the array is reached.
+func (s *jsonArrayStream) next(v interface{}) (bool, error) {
+	if s.done {
+		return false, nil
+	}
+
+	if !s.decoder.More() {
+		s.done = true
+
+		// consume the closing bracket, so that a truncated response is detected
+		_, err := s.decoder.Token()
 		if err != nil {
-			return err
+			return false, errors.Wrap(err, "JSON decoding failed")
 		}
 
-		httpErr.Url = url.String()
-		return httpErr
-	}
-
-	if h.debug {
-		fmt.Printf("\n<- POST %s t=%d - %s", url.String(), time.Now().Unix(), string(body))
+		return false, nil
 	}
 
-	err = json.Unmarshal(responseBody, &model)
+	err := s.decoder.Decode(v)
 	if err != nil {
-		return errors.Wrap(err, "JSON decoding failed")
+		return false, errors.Wrap(err, "JSON decoding failed")
 	}
 
-	return nil
+	return true, nil
+}
+
+func (s *jsonArrayStream) close() error {
+	return s.body.Close()
 }
 
 func (h *httpHandler) getBlockByID(ctx context.Context, ID string, opts ...queryOpts) (*models.Block, error) {
@@ -190,13 +295,12 @@ func (h *httpHandler) getBlockByID(ctx context.Context, ID string, opts ...query
 	return blocks[0], nil
 }
 
-func (h *httpHandler) getBlocksByHeights(
-	ctx context.Context,
+func (h *httpHandler) blocksByHeightsURL(
 	heights string,
 	startHeight string,
 	endHeight string,
 	opts ...queryOpts,
-) ([]*models.Block, error) {
+) (*url.URL, error) {
 	u := h.mustBuildURL("/blocks", opts...)
 
 	q := u.Query()
@@ -212,8 +316,23 @@ func (h *httpHandler) getBlocksByHeights(
 	q.Add("expand", "payload")
 	u.RawQuery = q.Encode()
 
+	return u, nil
+}
+
+func (h *httpHandler) getBlocksByHeights(
+	ctx context.Context,
+	heights string,
+	startHeight string,
+	endHeight string,
+	opts ...queryOpts,
+) ([]*models.Block, error) {
+	u, err := h.blocksByHeightsURL(heights, startHeight, endHeight, opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	var blocks []*models.Block
-	err := h.get(ctx, u, &blocks)
+	err = h.get(ctx, u, &blocks)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("get block by height %s failed", heights))
 	}
@@ -221,6 +340,26 @@ func (h *httpHandler) getBlocksByHeights(
 	return blocks, nil
 }
 
+func (h *httpHandler) getBlocksByHeightsStream(
+	ctx context.Context,
+	heights string,
+	startHeight string,
+	endHeight string,
+	opts ...queryOpts,
+) (*jsonArrayStream, error) {
+	u, err := h.blocksByHeightsURL(heights, startHeight, endHeight, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := h.getStream(ctx, u)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("get block by height %s failed", heights))
+	}
+
+	return stream, nil
+}
+
 func (h *httpHandler) getAccount(
 	ctx context.Context,
 	address string,
@@ -348,14 +487,13 @@ func (h *httpHandler) sendTransaction(ctx context.Context, transaction []byte, o
 	return h.post(ctx, h.mustBuildURL("/transactions", opts...), transaction, &tx)
 }
 
-func (h *httpHandler) getEvents(
-	ctx context.Context,
+func (h *httpHandler) eventsURL(
 	eventType string,
 	start string,
 	end string,
 	blockIDs []string,
 	opts ...queryOpts,
-) ([]models.BlockEvents, error) {
+) (*url.URL, error) {
 	u := h.mustBuildURL("/events", opts...)
 
 	q := u.Query()
@@ -371,8 +509,24 @@ func (h *httpHandler) getEvents(
 	q.Add("type", eventType)
 	u.RawQuery = q.Encode()
 
+	return u, nil
+}
+
+func (h *httpHandler) getEvents(
+	ctx context.Context,
+	eventType string,
+	start string,
+	end string,
+	blockIDs []string,
+	opts ...queryOpts,
+) ([]models.BlockEvents, error) {
+	u, err := h.eventsURL(eventType, start, end, blockIDs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	var events []models.BlockEvents
-	err := h.get(ctx, u, &events)
+	err = h.get(ctx, u, &events)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("get events by type %s failed", eventType))
 	}
@@ -380,6 +534,27 @@ func (h *httpHandler) getEvents(
 	return events, nil
 }
 
+func (h *httpHandler) getEventsStream(
+	ctx context.Context,
+	eventType string,
+	start string,
+	end string,
+	blockIDs []string,
+	opts ...queryOpts,
+) (*jsonArrayStream, error) {
+	u, err := h.eventsURL(eventType, start, end, blockIDs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := h.getStream(ctx, u)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("get events by type %s failed", eventType))
+	}
+
+	return stream, nil
+}
+
 func (h *httpHandler) getExecutionResults(
 	ctx context.Context,
 	blockIDs []string,
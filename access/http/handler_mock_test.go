@@ -160,6 +160,36 @@ func (_m *mockHandler) getBlocksByHeights(ctx context.Context, heights string, s
 	return r0, r1
 }
 
+// getBlocksByHeightsStream provides a mock function with given fields: ctx, heights, startHeight, endHeight, opts
+func (_m *mockHandler) getBlocksByHeightsStream(ctx context.Context, heights string, startHeight string, endHeight string, opts ...queryOpts) (*jsonArrayStream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, heights, startHeight, endHeight)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *jsonArrayStream
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...queryOpts) *jsonArrayStream); ok {
+		r0 = rf(ctx, heights, startHeight, endHeight, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jsonArrayStream)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ...queryOpts) error); ok {
+		r1 = rf(ctx, heights, startHeight, endHeight, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // getCollection provides a mock function with given fields: ctx, ID, opts
 func (_m *mockHandler) getCollection(ctx context.Context, ID string, opts ...queryOpts) (*models.Collection, error) {
 	_va := make([]interface{}, len(opts))
@@ -220,6 +250,36 @@ func (_m *mockHandler) getEvents(ctx context.Context, eventType string, start st
 	return r0, r1
 }
 
+// getEventsStream provides a mock function with given fields: ctx, eventType, start, end, blockIDs, opts
+func (_m *mockHandler) getEventsStream(ctx context.Context, eventType string, start string, end string, blockIDs []string, opts ...queryOpts) (*jsonArrayStream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, eventType, start, end, blockIDs)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *jsonArrayStream
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, ...queryOpts) *jsonArrayStream); ok {
+		r0 = rf(ctx, eventType, start, end, blockIDs, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jsonArrayStream)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string, ...queryOpts) error); ok {
+		r1 = rf(ctx, eventType, start, end, blockIDs, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // getExecutionResultByID provides a mock function with given fields: ctx, id, opts
 func (_m *mockHandler) getExecutionResultByID(ctx context.Context, id string, opts ...queryOpts) (*models.ExecutionResult, error) {
 	_va := make([]interface{}, len(opts))
@@ -330,3 +390,8 @@ func (_m *mockHandler) sendTransaction(ctx context.Context, transaction []byte,
 
 	return r0
 }
+
+// setMaxResponseSize provides a mock function with given fields: size
+func (_m *mockHandler) setMaxResponseSize(size int64) {
+	_m.Called(size)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/onflow/flow-go-sdk/access/http/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // handlerTest is a helper that builds handler with a http test server
@@ -116,6 +117,20 @@ func TestHandler_ResponseFailures(t *testing.T) {
 		_, err := handler.getBlocksByHeights(ctx, "1", "", "")
 		assert.EqualError(t, err, "get block by height 1 failed: JSON decoding failed: json: cannot unmarshal string into Go value of type []*models.Block")
 	}))
+
+	t.Run("Invalid Error Response", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
+		u := newBlocksURL(map[string]string{"height": "1"})
+		req.url = u
+		req.err = []byte("<html>Bad Gateway</html>")
+
+		_, err := handler.getBlocksByHeights(ctx, "1", "", "")
+
+		var httpErr HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Contains(t, httpErr.Url, u.String())
+		assert.Contains(t, err.Error(), "failed with status 400")
+	}))
 }
 
 func TestHandler_GetBlockByID(t *testing.T) {
@@ -532,6 +547,103 @@ func TestHandler_GetEvents(t *testing.T) {
 
 }
 
+func TestHandler_GetEventsStream(t *testing.T) {
+	const eventType = "A.Foo"
+	ids := []string{"0x1", "0x2"}
+	eventsURL := newEventsURL(map[string]string{"type": eventType}, ids)
+
+	t.Run("Success", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
+		httpEvents := []models.BlockEvents{blockEventsFlowFixture(), blockEventsFlowFixture()}
+		req.SetData(eventsURL, httpEvents)
+
+		stream, err := handler.getEventsStream(ctx, eventType, "", "", ids)
+		require.NoError(t, err)
+		defer stream.close()
+
+		var events []models.BlockEvents
+		for {
+			var event models.BlockEvents
+			ok, err := stream.next(&event)
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			events = append(events, event)
+		}
+
+		assert.Equal(t, httpEvents, events)
+	}))
+
+	t.Run("Truncated response", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
+		req.SetData(eventsURL, []models.BlockEvents{blockEventsFlowFixture()})
+		req.res = req.res[:len(req.res)-1]
+
+		stream, err := handler.getEventsStream(ctx, eventType, "", "", ids)
+		require.NoError(t, err)
+		defer stream.close()
+
+		var event models.BlockEvents
+		ok, err := stream.next(&event)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		_, err = stream.next(&event)
+		assert.Error(t, err)
+	}))
+
+	t.Run("Not an array", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
+		req.SetData(eventsURL, blockEventsFlowFixture())
+
+		_, err := handler.getEventsStream(ctx, eventType, "", "", ids)
+		assert.Error(t, err)
+	}))
+
+	t.Run("Failure response", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
+		req.SetErr(eventsURL, models.ModelError{
+			Code:    400,
+			Message: "events not found",
+		})
+
+		_, err := handler.getEventsStream(ctx, eventType, "", "", ids)
+		assert.EqualError(t, err, "get events by type A.Foo failed: events not found")
+	}))
+}
+
+func TestHandler_MaxResponseSize(t *testing.T) {
+	httpEvents := []models.BlockEvents{blockEventsFlowFixture()}
+	eventsURL := newEventsURL(map[string]string{"type": "A.Foo"}, []string{"0x1"})
+
+	t.Run("Within limit", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
+		req.SetData(eventsURL, httpEvents)
+		handler.setMaxResponseSize(int64(len(req.res)))
+
+		events, err := handler.getEvents(ctx, "A.Foo", "", "", []string{"0x1"})
+		require.NoError(t, err)
+		assert.Equal(t, httpEvents, events)
+	}))
+
+	t.Run("Exceeds limit", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
+		req.SetData(eventsURL, httpEvents)
+		handler.setMaxResponseSize(int64(len(req.res) - 1))
+
+		_, err := handler.getEvents(ctx, "A.Foo", "", "", []string{"0x1"})
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+	}))
+
+	t.Run("Exceeds limit while streaming", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
+		req.SetData(eventsURL, httpEvents)
+		handler.setMaxResponseSize(10)
+
+		stream, err := handler.getEventsStream(ctx, "A.Foo", "", "", []string{"0x1"})
+		require.NoError(t, err)
+		defer stream.close()
+
+		var event models.BlockEvents
+		_, err = stream.next(&event)
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+	}))
+}
+
 func TestHandler_GetExecResult(t *testing.T) {
 	t.Run("Collection by IDs", handlerTest(func(ctx context.Context, t *testing.T, handler httpHandler, req *testRequest) {
 		fixture := []models.ExecutionResult{executionResultFlowFixture()}
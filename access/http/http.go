@@ -48,6 +48,9 @@ type handler interface {
 	getEvents(ctx context.Context, eventType string, start string, end string, blockIDs []string, opts ...queryOpts) ([]models.BlockEvents, error)
 	getExecutionResultByID(ctx context.Context, id string, opts ...queryOpts) (*models.ExecutionResult, error)
 	getExecutionResults(ctx context.Context, blockIDs []string, opts ...queryOpts) ([]models.ExecutionResult, error)
+	getBlocksByHeightsStream(ctx context.Context, heights string, startHeight string, endHeight string, opts ...queryOpts) (*jsonArrayStream, error)
+	getEventsStream(ctx context.Context, eventType string, start string, end string, blockIDs []string, opts ...queryOpts) (*jsonArrayStream, error)
+	setMaxResponseSize(size int64)
 }
 
 // ExpandOpts allows you to define a list of fields that you want to retrieve as extra data in the response.
@@ -171,12 +174,32 @@ func NewBaseClient(host string) (*BaseClient, error) {
 type BaseClient struct {
 	handler     handler
 	jsonOptions []json.Option
+	lazyEvents  bool
 }
 
 func (c *BaseClient) SetJSONOptions(options []json.Option) {
 	c.jsonOptions = options
 }
 
+// SetLazyEventDecoding sets whether event payloads are decoded when events are fetched.
+//
+// If lazy is true, events are returned with their raw JSON-CDC payload only, and their Value
+// field stays zero. Event data should always be read with flow.Event.DecodeValue, which decodes
+// lazily fetched events on first access. DecodeValue does not know the options of this client:
+// it decodes with the default options unless it is passed the same options as given to
+// SetJSONOptions.
+func (c *BaseClient) SetLazyEventDecoding(lazy bool) {
+	c.lazyEvents = lazy
+}
+
+// SetMaxResponseSize sets the maximum size of a response body in bytes.
+//
+// Requests with a larger response fail with ErrResponseTooLarge. A size of zero,
+// the default, means that response sizes are not limited.
+func (c *BaseClient) SetMaxResponseSize(size int64) {
+	c.handler.setMaxResponseSize(size)
+}
+
 func (c *BaseClient) Ping(ctx context.Context) error {
 	_, err := c.handler.getBlocksByHeights(ctx, specialHeightMap[SEALED], "", "")
 	if err != nil {
@@ -225,6 +248,36 @@ func (c *BaseClient) GetBlocksByHeights(
 	return toBlocks(httpBlocks)
 }
 
+// IterateBlocksByHeights requests the blocks by the specified block query and returns an
+// iterator that decodes the blocks one at a time as the response is read.
+func (c *BaseClient) IterateBlocksByHeights(
+	ctx context.Context,
+	heightQuery HeightQuery,
+	opts ...queryOpts,
+) (*BlockIterator, error) {
+	if !heightQuery.heightsDefined() && !heightQuery.rangeDefined() {
+		return nil, fmt.Errorf("must either provide heights or start and end height range")
+	}
+
+	err := heightQuery.validateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := c.handler.getBlocksByHeightsStream(
+		ctx,
+		heightQuery.heightsString(),
+		heightQuery.startString(),
+		heightQuery.endString(),
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockIterator{stream: stream}, nil
+}
+
 func (c *BaseClient) GetCollection(
 	ctx context.Context,
 	ID flow.Identifier,
@@ -274,7 +327,7 @@ func (c *BaseClient) GetTransactionResult(
 		return nil, err
 	}
 
-	return toTransactionResult(tx.Result, c.jsonOptions)
+	return toTransactionResult(tx.Result, c.jsonOptions, c.lazyEvents)
 }
 
 func (c *BaseClient) GetAccountAtBlockHeight(
@@ -376,7 +429,7 @@ func (c *BaseClient) GetEventsForHeightRange(
 		return nil, err
 	}
 
-	return toBlockEvents(events, c.jsonOptions)
+	return toBlockEvents(events, c.jsonOptions, c.lazyEvents)
 }
 
 func (c *BaseClient) GetEventsForBlockIDs(
@@ -394,7 +447,65 @@ func (c *BaseClient) GetEventsForBlockIDs(
 		return nil, err
 	}
 
-	return toBlockEvents(events, c.jsonOptions)
+	return toBlockEvents(events, c.jsonOptions, c.lazyEvents)
+}
+
+// IterateEventsForHeightRange requests the events of the given type in the height range and
+// returns an iterator that decodes the block events one at a time as the response is read.
+func (c *BaseClient) IterateEventsForHeightRange(
+	ctx context.Context,
+	eventType string,
+	heightQuery HeightQuery,
+) (*BlockEventsIterator, error) {
+	if !heightQuery.rangeDefined() {
+		return nil, fmt.Errorf("must provide start and end height range")
+	}
+
+	err := heightQuery.validateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := c.handler.getEventsStream(
+		ctx,
+		eventType,
+		heightQuery.startString(),
+		heightQuery.endString(),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newBlockEventsIterator(stream), nil
+}
+
+// IterateEventsForBlockIDs requests the events of the given type in the blocks and
+// returns an iterator that decodes the block events one at a time as the response is read.
+func (c *BaseClient) IterateEventsForBlockIDs(
+	ctx context.Context,
+	eventType string,
+	blockIDs []flow.Identifier,
+) (*BlockEventsIterator, error) {
+	ids := make([]string, len(blockIDs))
+	for i, id := range blockIDs {
+		ids[i] = id.String()
+	}
+
+	stream, err := c.handler.getEventsStream(ctx, eventType, "", "", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newBlockEventsIterator(stream), nil
+}
+
+func (c *BaseClient) newBlockEventsIterator(stream *jsonArrayStream) *BlockEventsIterator {
+	return &BlockEventsIterator{
+		stream:      stream,
+		jsonOptions: c.jsonOptions,
+		lazyEvents:  c.lazyEvents,
+	}
 }
 
 func (c *BaseClient) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
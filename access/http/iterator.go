@@ -0,0 +1,122 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/http/models"
+)
+
+// A BlockEventsIterator iterates over block events as they are decoded from a response.
+//
+// Call Next to advance the iterator and Value to get the current block events. Next returns
+// false once all block events are read or decoding fails, in which case Err returns the error.
+// The iterator must be closed to release the underlying connection.
+type BlockEventsIterator struct {
+	stream      *jsonArrayStream
+	jsonOptions []json.Option
+	lazyEvents  bool
+	value       flow.BlockEvents
+	err         error
+}
+
+// Next decodes the next block events and returns true if there are any.
+func (it *BlockEventsIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	var m models.BlockEvents
+	ok, err := it.stream.next(&m)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	it.value, it.err = toBlockEvent(m, it.jsonOptions, it.lazyEvents)
+
+	return it.err == nil
+}
+
+// Value returns the block events decoded by the last call to Next.
+func (it *BlockEventsIterator) Value() flow.BlockEvents {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *BlockEventsIterator) Err() error {
+	return it.err
+}
+
+// Close closes the response body.
+func (it *BlockEventsIterator) Close() error {
+	return it.stream.close()
+}
+
+// A BlockIterator iterates over blocks as they are decoded from a response.
+//
+// Call Next to advance the iterator and Value to get the current block. Next returns
+// false once all blocks are read or decoding fails, in which case Err returns the error.
+// The iterator must be closed to release the underlying connection.
+type BlockIterator struct {
+	stream *jsonArrayStream
+	value  *flow.Block
+	err    error
+}
+
+// Next decodes the next block and returns true if there is one.
+func (it *BlockIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	var m models.Block
+	ok, err := it.stream.next(&m)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	it.value, it.err = toBlock(&m)
+
+	return it.err == nil
+}
+
+// Value returns the block decoded by the last call to Next.
+func (it *BlockIterator) Value() *flow.Block {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// Close closes the response body.
+func (it *BlockIterator) Close() error {
+	return it.stream.close()
+}
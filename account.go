@@ -86,13 +86,19 @@ func (a *AccountKey) SetWeight(weight int) *AccountKey {
 
 // Encode returns the canonical RLP byte representation of this account key.
 func (a AccountKey) Encode() []byte {
-	temp := accountKeyWrapper{
-		EncodedPublicKey: a.PublicKey.Encode(),
-		SigAlgo:          uint(a.SigAlgo),
-		HashAlgo:         uint(a.HashAlgo),
-		Weight:           uint(a.Weight),
-	}
-	return mustRLPEncode(&temp)
+	encodedPublicKey := a.PublicKey.Encode()
+
+	contentSize := rlpBytesSize(encodedPublicKey) +
+		rlpUintSize(uint64(a.SigAlgo)) +
+		rlpUintSize(uint64(a.HashAlgo)) +
+		rlpUintSize(uint64(a.Weight))
+
+	buf := make([]byte, 0, rlpListSize(contentSize))
+	buf = rlpAppendListHeader(buf, contentSize)
+	buf = rlpAppendBytes(buf, encodedPublicKey)
+	buf = rlpAppendUint(buf, uint64(a.SigAlgo))
+	buf = rlpAppendUint(buf, uint64(a.HashAlgo))
+	return rlpAppendUint(buf, uint64(a.Weight))
 }
 
 // accountCompatibleAlgorithms returns true if the signature and hash algorithms are a valid pair
@@ -125,9 +131,7 @@ func (a AccountKey) Validate() error {
 
 // DecodeAccountKey decodes the RLP byte representation of an account key.
 func DecodeAccountKey(b []byte) (*AccountKey, error) {
-	var temp accountKeyWrapper
-
-	err := rlpDecode(b, &temp)
+	temp, err := decodeAccountKeyWrapper(b)
 	if err != nil {
 		return nil, err
 	}
@@ -144,13 +148,50 @@ func DecodeAccountKey(b []byte) (*AccountKey, error) {
 		PublicKey: publicKey,
 		SigAlgo:   sigAlgo,
 		HashAlgo:  hashAlgo,
-		Weight:    int(temp.Weight),
+		Weight:    temp.Weight,
 	}, nil
 }
 
 type accountKeyWrapper struct {
 	EncodedPublicKey []byte
-	SigAlgo          uint
-	HashAlgo         uint
-	Weight           uint
+	SigAlgo          int
+	HashAlgo         int
+	Weight           int
+}
+
+func decodeAccountKeyWrapper(b []byte) (accountKeyWrapper, error) {
+	var temp accountKeyWrapper
+
+	d := newRLPDecoder(b)
+	list, err := d.listDecoder()
+	if err != nil {
+		return temp, err
+	}
+
+	temp.EncodedPublicKey, err = list.bytes()
+	if err != nil {
+		return temp, withField(err, "publicKey")
+	}
+
+	temp.SigAlgo, err = list.int()
+	if err != nil {
+		return temp, withField(err, "sigAlgo")
+	}
+
+	temp.HashAlgo, err = list.int()
+	if err != nil {
+		return temp, withField(err, "hashAlgo")
+	}
+
+	temp.Weight, err = list.int()
+	if err != nil {
+		return temp, withField(err, "weight")
+	}
+
+	err = list.finish()
+	if err != nil {
+		return temp, err
+	}
+
+	return temp, d.finish()
 }
@@ -35,6 +35,8 @@ var (
 	ErrInvalidNonce = errors.New("invalid nonce")
 	// ErrInvalidAppID is returned when the account proof app ID passed to a function is invalid.
 	ErrInvalidAppID = errors.New("invalid app ID")
+	// ErrAccountProofAddressMismatch is returned when an account proof signature is from a different account.
+	ErrAccountProofAddressMismatch = errors.New("signature address does not match account")
 )
 
 type canonicalAccountProof struct {
@@ -70,3 +72,72 @@ func EncodeAccountProofMessage(address Address, appID, nonceHex string) ([]byte,
 
 	return msg, nil
 }
+
+// An AccountProofSignature is one of the composite signatures of an account proof, as
+// returned by the FCL authentication service.
+type AccountProofSignature struct {
+	Address  Address `json:"addr"`
+	KeyIndex int     `json:"keyId"`
+	// Signature is the hex-encoded signature.
+	Signature string `json:"signature"`
+}
+
+// An AccountProofError is returned when an account proof cannot be verified.
+//
+// The underlying error can be matched with errors.Is, for example against ErrInvalidSignature
+// or ErrInsufficientKeyWeight.
+type AccountProofError struct {
+	Address Address
+	Err     error
+}
+
+func (e AccountProofError) Error() string {
+	return fmt.Sprintf("invalid account proof for %s: %s", e.Address, e.Err)
+}
+
+func (e AccountProofError) Unwrap() error {
+	return e.Err
+}
+
+// VerifyAccountProof verifies the signatures of an account proof for the given app ID and nonce
+// against the keys of the account.
+//
+// All signatures must be from the account, be valid for a non-revoked key and the keys must have
+// a total weight of at least AccountKeyWeightThreshold. An AccountProofError is returned otherwise.
+//
+// The account keys should be fetched at a block that is recent enough for the proof, for
+// example with access.Client.GetAccountAtBlockHeight.
+func VerifyAccountProof(account *Account, appID string, nonceHex string, sigs []AccountProofSignature) error {
+	message, err := EncodeAccountProofMessage(account.Address, appID, nonceHex)
+	if err != nil {
+		return AccountProofError{Address: account.Address, Err: err}
+	}
+
+	signatures := make([][]byte, len(sigs))
+	keyIndices := make([]int, len(sigs))
+	for i, sig := range sigs {
+		if sig.Address != account.Address {
+			return AccountProofError{
+				Address: account.Address,
+				Err:     fmt.Errorf("%w: %s", ErrAccountProofAddressMismatch, sig.Address),
+			}
+		}
+
+		signatures[i], err = hex.DecodeString(strings.TrimPrefix(sig.Signature, "0x"))
+		if err != nil {
+			return AccountProofError{
+				Address: account.Address,
+				Err:     fmt.Errorf("%w from key %d: %s", ErrInvalidSignature, sig.KeyIndex, err),
+			}
+		}
+
+		keyIndices[i] = sig.KeyIndex
+	}
+
+	err = VerifyUserSignature(account, message, signatures, keyIndices)
+	if err != nil {
+		return AccountProofError{Address: account.Address, Err: err}
+	}
+
+	return nil
+}
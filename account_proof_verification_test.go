@@ -0,0 +1,108 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestVerifyAccountProof(t *testing.T) {
+	const appID = "AWESOME-APP-ID"
+	const nonce = "3037366134636339643564623330316636626239323161663465346131393662"
+
+	sign := func(t *testing.T, a *signingAccount, keyIndex int) flow.AccountProofSignature {
+		message, err := flow.EncodeAccountProofMessage(a.account.Address, appID, nonce)
+		require.NoError(t, err)
+
+		sig, err := flow.SignUserMessage(a.signers[keyIndex], message)
+		require.NoError(t, err)
+
+		return flow.AccountProofSignature{
+			Address:   a.account.Address,
+			KeyIndex:  keyIndex,
+			Signature: hex.EncodeToString(sig),
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		a := newSigningAccounts([]int{500, 500})[0]
+
+		err := flow.VerifyAccountProof(a.account, appID, nonce, []flow.AccountProofSignature{sign(t, a, 0), sign(t, a, 1)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("FCL composite signatures", func(t *testing.T) {
+		a := newSigningAccounts([]int{1000})[0]
+		sig := sign(t, a, 0)
+
+		data := fmt.Sprintf(
+			`[{"f_type":"CompositeSignature","f_vsn":"1.0.0","addr":"0x%s","keyId":0,"signature":"%s"}]`,
+			a.account.Address.Hex(),
+			sig.Signature,
+		)
+
+		var sigs []flow.AccountProofSignature
+		require.NoError(t, json.Unmarshal([]byte(data), &sigs))
+		assert.NoError(t, flow.VerifyAccountProof(a.account, appID, nonce, sigs))
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		a := newSigningAccounts([]int{500, 500})[0]
+
+		err := flow.VerifyAccountProof(a.account, appID, nonce, []flow.AccountProofSignature{sign(t, a, 0)})
+
+		var proofErr flow.AccountProofError
+		require.ErrorAs(t, err, &proofErr)
+		assert.Equal(t, a.account.Address, proofErr.Address)
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Wrong nonce", func(t *testing.T) {
+		a := newSigningAccounts([]int{1000})[0]
+
+		err := flow.VerifyAccountProof(a.account, appID, nonce+"00", []flow.AccountProofSignature{sign(t, a, 0)})
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+
+		err = flow.VerifyAccountProof(a.account, appID, "00", []flow.AccountProofSignature{sign(t, a, 0)})
+		assert.ErrorIs(t, err, flow.ErrInvalidNonce)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		a := newSigningAccounts([]int{1000})[0]
+		a.account.Keys[0].Revoked = true
+
+		err := flow.VerifyAccountProof(a.account, appID, nonce, []flow.AccountProofSignature{sign(t, a, 0)})
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+	})
+
+	t.Run("Other account", func(t *testing.T) {
+		accounts := newSigningAccounts([]int{1000}, []int{1000})
+
+		err := flow.VerifyAccountProof(accounts[0].account, appID, nonce, []flow.AccountProofSignature{sign(t, accounts[1], 0)})
+		assert.ErrorIs(t, err, flow.ErrAccountProofAddressMismatch)
+	})
+}
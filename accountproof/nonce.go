@@ -0,0 +1,230 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accountproof
+
+import (
+	"container/heap"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+const (
+	// DefaultNonceTTL is the default time after which an issued nonce expires.
+	DefaultNonceTTL = 5 * time.Minute
+	// DefaultNonceLength is the default length of issued nonces in bytes.
+	DefaultNonceLength = flow.AccountProofNonceMinLenBytes
+)
+
+var (
+	// ErrUnknownNonce is returned when a nonce was not issued, or has already been consumed.
+	ErrUnknownNonce = errors.New("unknown nonce")
+	// ErrExpiredNonce is returned when a nonce is consumed after it expired.
+	ErrExpiredNonce = errors.New("expired nonce")
+)
+
+// A NonceStore stores issued nonces until they are consumed.
+//
+// Implementations must be safe for concurrent use, and Take must remove the nonce atomically
+// so that a nonce can only be taken once, even by concurrent callers.
+type NonceStore interface {
+	// Put stores a nonce that expires at the given time.
+	Put(ctx context.Context, nonce string, expiresAt time.Time) error
+	// Take removes a nonce from the store and returns its expiry time,
+	// or false if the nonce is not in the store.
+	Take(ctx context.Context, nonce string) (expiresAt time.Time, ok bool, err error)
+}
+
+// A NonceIssuer issues random nonces for account proofs and checks that each nonce is
+// consumed at most once before it expires.
+type NonceIssuer struct {
+	store  NonceStore
+	ttl    time.Duration
+	length int
+}
+
+// A NonceIssuerOption configures a NonceIssuer.
+type NonceIssuerOption func(*NonceIssuer)
+
+// WithNonceTTL sets the time after which issued nonces expire.
+func WithNonceTTL(ttl time.Duration) NonceIssuerOption {
+	return func(i *NonceIssuer) {
+		i.ttl = ttl
+	}
+}
+
+// WithNonceLength sets the length of issued nonces in bytes.
+//
+// Lengths below flow.AccountProofNonceMinLenBytes are rejected by NewNonceIssuer.
+func WithNonceLength(length int) NonceIssuerOption {
+	return func(i *NonceIssuer) {
+		i.length = length
+	}
+}
+
+// NewNonceIssuer creates a nonce issuer that keeps issued nonces in the given store.
+func NewNonceIssuer(store NonceStore, opts ...NonceIssuerOption) (*NonceIssuer, error) {
+	i := &NonceIssuer{
+		store:  store,
+		ttl:    DefaultNonceTTL,
+		length: DefaultNonceLength,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	if i.length < flow.AccountProofNonceMinLenBytes {
+		return nil, fmt.Errorf(
+			"%w: nonce must be at least %d bytes",
+			flow.ErrInvalidNonce,
+			flow.AccountProofNonceMinLenBytes,
+		)
+	}
+
+	return i, nil
+}
+
+// Issue generates a new random nonce, hex-encoded, and stores it until it expires.
+func (i *NonceIssuer) Issue(ctx context.Context) (string, error) {
+	b := make([]byte, i.length)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	nonce := hex.EncodeToString(b)
+
+	err = i.store.Put(ctx, nonce, time.Now().Add(i.ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to store nonce: %w", err)
+	}
+
+	return nonce, nil
+}
+
+// Consume checks that the nonce was issued and has not expired, and removes it so that it
+// cannot be used again.
+func (i *NonceIssuer) Consume(ctx context.Context, nonce string) error {
+	expiresAt, ok, err := i.store.Take(ctx, nonce)
+	if err != nil {
+		return fmt.Errorf("failed to load nonce: %w", err)
+	}
+
+	if !ok {
+		return ErrUnknownNonce
+	}
+
+	if time.Now().After(expiresAt) {
+		return ErrExpiredNonce
+	}
+
+	return nil
+}
+
+// A MemoryNonceStore is a NonceStore that keeps nonces in memory.
+//
+// Expired nonces are pruned whenever a nonce is added. Nonces are tracked in order of expiry,
+// so pruning only visits the nonces that have expired.
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	expiry nonceExpiryHeap
+}
+
+var _ NonceStore = (*MemoryNonceStore)(nil)
+
+// NewMemoryNonceStore creates an empty in-memory nonce store.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// Put stores a nonce that expires at the given time.
+func (s *MemoryNonceStore) Put(_ context.Context, nonce string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for len(s.expiry) > 0 && now.After(s.expiry[0].expiresAt) {
+		expired := heap.Pop(&s.expiry).(nonceExpiry)
+
+		// the nonce may have been taken, or stored again with a later expiry
+		if e, ok := s.nonces[expired.nonce]; ok && e.Equal(expired.expiresAt) {
+			delete(s.nonces, expired.nonce)
+		}
+	}
+
+	s.nonces[nonce] = expiresAt
+	heap.Push(&s.expiry, nonceExpiry{nonce: nonce, expiresAt: expiresAt})
+
+	return nil
+}
+
+// Take removes a nonce from the store and returns its expiry time.
+func (s *MemoryNonceStore) Take(_ context.Context, nonce string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.nonces[nonce]
+	if ok {
+		delete(s.nonces, nonce)
+	}
+
+	return expiresAt, ok, nil
+}
+
+// Len returns the number of nonces in the store.
+func (s *MemoryNonceStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.nonces)
+}
+
+type nonceExpiry struct {
+	nonce     string
+	expiresAt time.Time
+}
+
+// nonceExpiryHeap is a min-heap of nonces ordered by expiry time.
+type nonceExpiryHeap []nonceExpiry
+
+func (h nonceExpiryHeap) Len() int           { return len(h) }
+func (h nonceExpiryHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h nonceExpiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *nonceExpiryHeap) Push(x interface{}) {
+	*h = append(*h, x.(nonceExpiry))
+}
+
+func (h *nonceExpiryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
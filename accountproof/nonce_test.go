@@ -0,0 +1,145 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accountproof_test
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/accountproof"
+)
+
+func TestNonceIssuer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Issue and consume", func(t *testing.T) {
+		store := accountproof.NewMemoryNonceStore()
+		issuer, err := accountproof.NewNonceIssuer(store)
+		require.NoError(t, err)
+
+		nonce, err := issuer.Issue(ctx)
+		require.NoError(t, err)
+
+		b, err := hex.DecodeString(nonce)
+		require.NoError(t, err)
+		assert.Len(t, b, accountproof.DefaultNonceLength)
+		assert.Equal(t, 1, store.Len())
+
+		assert.NoError(t, issuer.Consume(ctx, nonce))
+		assert.ErrorIs(t, issuer.Consume(ctx, nonce), accountproof.ErrUnknownNonce)
+		assert.Equal(t, 0, store.Len())
+	})
+
+	t.Run("Unknown nonce", func(t *testing.T) {
+		issuer, err := accountproof.NewNonceIssuer(accountproof.NewMemoryNonceStore())
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, issuer.Consume(ctx, "00"), accountproof.ErrUnknownNonce)
+	})
+
+	t.Run("Expired nonce", func(t *testing.T) {
+		store := accountproof.NewMemoryNonceStore()
+		issuer, err := accountproof.NewNonceIssuer(store, accountproof.WithNonceTTL(time.Millisecond))
+		require.NoError(t, err)
+
+		nonce, err := issuer.Issue(ctx)
+		require.NoError(t, err)
+
+		time.Sleep(5 * time.Millisecond)
+		assert.ErrorIs(t, issuer.Consume(ctx, nonce), accountproof.ErrExpiredNonce)
+
+		// expired nonces are pruned when new nonces are issued
+		_, err = issuer.Issue(ctx)
+		require.NoError(t, err)
+		_, err = issuer.Issue(ctx)
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+		_, err = issuer.Issue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, store.Len())
+	})
+
+	t.Run("Nonce length", func(t *testing.T) {
+		_, err := accountproof.NewNonceIssuer(accountproof.NewMemoryNonceStore(), accountproof.WithNonceLength(16))
+		assert.ErrorIs(t, err, flow.ErrInvalidNonce)
+
+		issuer, err := accountproof.NewNonceIssuer(accountproof.NewMemoryNonceStore(), accountproof.WithNonceLength(64))
+		require.NoError(t, err)
+
+		nonce, err := issuer.Issue(ctx)
+		require.NoError(t, err)
+		assert.Len(t, nonce, 128)
+	})
+
+	t.Run("Concurrent consumption", func(t *testing.T) {
+		issuer, err := accountproof.NewNonceIssuer(accountproof.NewMemoryNonceStore())
+		require.NoError(t, err)
+
+		nonce, err := issuer.Issue(ctx)
+		require.NoError(t, err)
+
+		var consumed int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if issuer.Consume(ctx, nonce) == nil {
+					atomic.AddInt32(&consumed, 1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), consumed)
+	})
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	ctx := context.Background()
+	store := accountproof.NewMemoryNonceStore()
+	now := time.Now()
+
+	require.NoError(t, store.Put(ctx, "expired", now.Add(-time.Minute)))
+	require.NoError(t, store.Put(ctx, "taken", now.Add(5*time.Millisecond)))
+	require.NoError(t, store.Put(ctx, "renewed", now.Add(5*time.Millisecond)))
+	require.NoError(t, store.Put(ctx, "renewed", now.Add(time.Minute)))
+	assert.Equal(t, 2, store.Len())
+
+	_, ok, err := store.Take(ctx, "taken")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.Put(ctx, "fresh", now.Add(time.Minute)))
+	assert.Equal(t, 2, store.Len())
+
+	expiresAt, ok, err := store.Take(ctx, "renewed")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, expiresAt.Equal(now.Add(time.Minute)))
+}
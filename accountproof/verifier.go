@@ -0,0 +1,121 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package accountproof verifies FCL account proofs against the keys of accounts on chain,
+// and issues the nonces that account proofs are made for.
+package accountproof
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// AccountGetter is the subset of the access API required to load account keys.
+type AccountGetter interface {
+	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
+	GetAccountAtBlockHeight(ctx context.Context, address flow.Address, blockHeight uint64) (*flow.Account, error)
+}
+
+// A Verifier verifies account proofs for an app.
+type Verifier struct {
+	client AccountGetter
+	appID  string
+	nonces *NonceIssuer
+}
+
+// A VerifierOption configures a Verifier.
+type VerifierOption func(*Verifier)
+
+// WithNonceIssuer makes the verifier consume the nonce of each valid account proof from the
+// given issuer, so that only nonces issued by the app are accepted and each nonce is only
+// accepted once.
+func WithNonceIssuer(issuer *NonceIssuer) VerifierOption {
+	return func(v *Verifier) {
+		v.nonces = issuer
+	}
+}
+
+// NewVerifier creates a verifier for account proofs of the given app.
+func NewVerifier(client AccountGetter, appID string, opts ...VerifierOption) *Verifier {
+	v := &Verifier{
+		client: client,
+		appID:  appID,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Verify verifies an account proof against the keys of the account at the latest sealed block.
+func (v *Verifier) Verify(
+	ctx context.Context,
+	address flow.Address,
+	nonce string,
+	sigs []flow.AccountProofSignature,
+) error {
+	return v.verify(ctx, address, nonce, sigs, func() (*flow.Account, error) {
+		return v.client.GetAccountAtLatestBlock(ctx, address)
+	})
+}
+
+// VerifyAtHeight verifies an account proof against the keys of the account at the given block height.
+func (v *Verifier) VerifyAtHeight(
+	ctx context.Context,
+	height uint64,
+	address flow.Address,
+	nonce string,
+	sigs []flow.AccountProofSignature,
+) error {
+	return v.verify(ctx, address, nonce, sigs, func() (*flow.Account, error) {
+		return v.client.GetAccountAtBlockHeight(ctx, address, height)
+	})
+}
+
+func (v *Verifier) verify(
+	ctx context.Context,
+	address flow.Address,
+	nonce string,
+	sigs []flow.AccountProofSignature,
+	getAccount func() (*flow.Account, error),
+) error {
+	account, err := getAccount()
+	if err != nil {
+		return fmt.Errorf("failed to get account %s: %w", address, err)
+	}
+
+	err = flow.VerifyAccountProof(account, v.appID, nonce, sigs)
+	if err != nil {
+		return err
+	}
+
+	// the nonce is only consumed once the proof is known to be valid, so that a failed
+	// account lookup does not force the wallet to create a new proof
+	if v.nonces != nil {
+		err = v.nonces.Consume(ctx, nonce)
+		if err != nil {
+			return flow.AccountProofError{Address: address, Err: err}
+		}
+	}
+
+	return nil
+}
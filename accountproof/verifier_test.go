@@ -0,0 +1,144 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accountproof_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/accountproof"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	_ accountproof.AccountGetter = (*grpc.Client)(nil)
+	_ accountproof.AccountGetter = (*http.Client)(nil)
+)
+
+const appID = "AWESOME-APP-ID"
+
+type mockAccountGetter struct {
+	accounts map[uint64]*flow.Account
+	latest   uint64
+}
+
+func (m *mockAccountGetter) GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return m.GetAccountAtBlockHeight(ctx, address, m.latest)
+}
+
+func (m *mockAccountGetter) GetAccountAtBlockHeight(_ context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	account, ok := m.accounts[height]
+	if !ok || account.Address != address {
+		return nil, errors.New("account not found")
+	}
+	return account, nil
+}
+
+func newAccount() (*flow.Account, crypto.Signer) {
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+	key.Index = 0
+
+	return &flow.Account{
+		Address: test.AddressGenerator().New(),
+		Keys:    []*flow.AccountKey{key},
+	}, signer
+}
+
+func signProof(t *testing.T, account *flow.Account, signer crypto.Signer, nonce string) []flow.AccountProofSignature {
+	message, err := flow.EncodeAccountProofMessage(account.Address, appID, nonce)
+	require.NoError(t, err)
+
+	sig, err := flow.SignUserMessage(signer, message)
+	require.NoError(t, err)
+
+	return []flow.AccountProofSignature{{
+		Address:   account.Address,
+		KeyIndex:  0,
+		Signature: hex.EncodeToString(sig),
+	}}
+}
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Verify at latest and at height", func(t *testing.T) {
+		account, signer := newAccount()
+
+		revoked := *account.Keys[0]
+		revoked.Revoked = true
+
+		client := &mockAccountGetter{
+			accounts: map[uint64]*flow.Account{
+				10: account,
+				20: {Address: account.Address, Keys: []*flow.AccountKey{&revoked}},
+			},
+			latest: 20,
+		}
+
+		verifier := accountproof.NewVerifier(client, appID)
+
+		nonce := hex.EncodeToString(make([]byte, 32))
+		sigs := signProof(t, account, signer, nonce)
+
+		assert.NoError(t, verifier.VerifyAtHeight(ctx, 10, account.Address, nonce, sigs))
+		assert.ErrorIs(t, verifier.Verify(ctx, account.Address, nonce, sigs), flow.ErrInvalidSignature)
+		assert.Error(t, verifier.VerifyAtHeight(ctx, 30, account.Address, nonce, sigs))
+	})
+
+	t.Run("With nonce issuer", func(t *testing.T) {
+		account, signer := newAccount()
+		client := &mockAccountGetter{
+			accounts: map[uint64]*flow.Account{1: account},
+			latest:   1,
+		}
+
+		issuer, err := accountproof.NewNonceIssuer(accountproof.NewMemoryNonceStore())
+		require.NoError(t, err)
+
+		verifier := accountproof.NewVerifier(client, appID, accountproof.WithNonceIssuer(issuer))
+
+		nonce, err := issuer.Issue(ctx)
+		require.NoError(t, err)
+		sigs := signProof(t, account, signer, nonce)
+
+		// failed lookups leave the nonce to be retried
+		assert.Error(t, verifier.VerifyAtHeight(ctx, 2, account.Address, nonce, sigs))
+		assert.NoError(t, verifier.Verify(ctx, account.Address, nonce, sigs))
+
+		// replayed proof
+		err = verifier.Verify(ctx, account.Address, nonce, sigs)
+		var proofErr flow.AccountProofError
+		require.ErrorAs(t, err, &proofErr)
+		assert.ErrorIs(t, err, accountproof.ErrUnknownNonce)
+
+		// nonce not issued by the app
+		nonce = hex.EncodeToString(make([]byte, 32))
+		err = verifier.Verify(ctx, account.Address, nonce, signProof(t, account, signer, nonce))
+		assert.ErrorIs(t, err, accountproof.ErrUnknownNonce)
+	})
+}
@@ -47,6 +47,18 @@ const (
 	BlockStatusSealed
 )
 
+// String returns the string representation of the block status, as used by the REST API.
+func (s BlockStatus) String() string {
+	switch s {
+	case BlockStatusFinalized:
+		return "BLOCK_FINALIZED"
+	case BlockStatusSealed:
+		return "BLOCK_SEALED"
+	default:
+		return "BLOCK_UNKNOWN"
+	}
+}
+
 func BlockStatusFromString(s string) BlockStatus {
 	switch s {
 	case "BLOCK_FINALIZED":
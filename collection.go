@@ -30,17 +30,17 @@ func (c Collection) ID() Identifier {
 
 // Encode returns the canonical RLP byte representation of this collection.
 func (c Collection) Encode() []byte {
-	transactionIDs := make([][]byte, len(c.TransactionIDs))
-	for i, id := range c.TransactionIDs {
-		transactionIDs[i] = id.Bytes()
-	}
+	transactionIDsSize := len(c.TransactionIDs) * rlpBytesSize(EmptyID[:])
+	contentSize := rlpListSize(transactionIDsSize)
 
-	temp := struct {
-		TransactionIDs [][]byte
-	}{
-		TransactionIDs: transactionIDs,
+	buf := make([]byte, 0, rlpListSize(contentSize))
+	buf = rlpAppendListHeader(buf, contentSize)
+	buf = rlpAppendListHeader(buf, transactionIDsSize)
+	for i := range c.TransactionIDs {
+		buf = rlpAppendBytes(buf, c.TransactionIDs[i][:])
 	}
-	return mustRLPEncode(&temp)
+
+	return buf
 }
 
 // A CollectionGuarantee is an attestation signed by the nodes that have guaranteed a collection.
@@ -0,0 +1,224 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package contracts provides the addresses of the Flow core contracts on each network.
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+// Name is the name of a core contract.
+type Name string
+
+// Names of the core contracts.
+const (
+	FungibleToken              Name = "FungibleToken"
+	FungibleTokenMetadataViews Name = "FungibleTokenMetadataViews"
+	FlowToken                  Name = "FlowToken"
+	FlowFees                   Name = "FlowFees"
+	FlowStorageFees            Name = "FlowStorageFees"
+	FlowServiceAccount         Name = "FlowServiceAccount"
+	NonFungibleToken           Name = "NonFungibleToken"
+	MetadataViews              Name = "MetadataViews"
+	ViewResolver               Name = "ViewResolver"
+	FlowIDTableStaking         Name = "FlowIDTableStaking"
+	FlowEpoch                  Name = "FlowEpoch"
+	FlowClusterQC              Name = "FlowClusterQC"
+	FlowDKG                    Name = "FlowDKG"
+	LockedTokens               Name = "LockedTokens"
+	FlowStakingCollection      Name = "FlowStakingCollection"
+	StakingProxy               Name = "StakingProxy"
+)
+
+var (
+	// ErrUnsupportedChain is returned for a chain ID without known core contract addresses.
+	ErrUnsupportedChain = errors.New("unsupported chain")
+	// ErrUnknownContract is returned for a contract that is not a core contract.
+	ErrUnknownContract = errors.New("unknown core contract")
+)
+
+// A Registry holds the addresses of the core contracts on a network.
+type Registry struct {
+	chainID   flow.ChainID
+	addresses map[Name]flow.Address
+}
+
+// ForChain returns the registry of core contract addresses on the given network.
+//
+// All flow.ChainID constants are supported. On every network bootstrapped by Flow, FungibleToken,
+// FlowToken and FlowFees are deployed to the second, third and fourth generated account, and the
+// remaining core contracts to the service account, except on Mainnet and Testnet where a number
+// of them live in dedicated accounts.
+func ForChain(chainID flow.ChainID) (*Registry, error) {
+	var addresses map[Name]flow.Address
+
+	switch chainID {
+	case flow.Mainnet:
+		addresses = bootstrapped(chainID)
+		addresses[NonFungibleToken] = flow.HexToAddress("1d7e57aa55817448")
+		addresses[MetadataViews] = flow.HexToAddress("1d7e57aa55817448")
+		addresses[ViewResolver] = flow.HexToAddress("1d7e57aa55817448")
+		addresses[FlowIDTableStaking] = flow.HexToAddress("8624b52f9ddcd04a")
+		addresses[FlowEpoch] = flow.HexToAddress("8624b52f9ddcd04a")
+		addresses[FlowClusterQC] = flow.HexToAddress("8624b52f9ddcd04a")
+		addresses[FlowDKG] = flow.HexToAddress("8624b52f9ddcd04a")
+		addresses[LockedTokens] = flow.HexToAddress("8d0e87b65159ae63")
+		addresses[FlowStakingCollection] = flow.HexToAddress("8d0e87b65159ae63")
+		addresses[StakingProxy] = flow.HexToAddress("62430cf28c26d095")
+	case flow.Testnet:
+		addresses = bootstrapped(chainID)
+		addresses[NonFungibleToken] = flow.HexToAddress("631e88ae7f1d7c20")
+		addresses[MetadataViews] = flow.HexToAddress("631e88ae7f1d7c20")
+		addresses[ViewResolver] = flow.HexToAddress("631e88ae7f1d7c20")
+		addresses[FlowIDTableStaking] = flow.HexToAddress("9eca2b38b18b5dfe")
+		addresses[FlowEpoch] = flow.HexToAddress("9eca2b38b18b5dfe")
+		addresses[FlowClusterQC] = flow.HexToAddress("9eca2b38b18b5dfe")
+		addresses[FlowDKG] = flow.HexToAddress("9eca2b38b18b5dfe")
+		addresses[LockedTokens] = flow.HexToAddress("95e019a17d0e23d7")
+		addresses[FlowStakingCollection] = flow.HexToAddress("95e019a17d0e23d7")
+		addresses[StakingProxy] = flow.HexToAddress("7aad92e5a0715d21")
+	case flow.Sandboxnet, flow.Benchnet, flow.Localnet, flow.Emulator, flow.BftTestnet:
+		addresses = bootstrapped(chainID)
+	case flow.MonotonicEmulator:
+		// the monotonic emulator generates addresses sequentially
+		addresses = withServiceAccount(
+			flow.HexToAddress("01"),
+			flow.HexToAddress("02"),
+			flow.HexToAddress("03"),
+			flow.HexToAddress("04"),
+		)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChain, chainID)
+	}
+
+	return &Registry{
+		chainID:   chainID,
+		addresses: addresses,
+	}, nil
+}
+
+// bootstrapped returns the core contract addresses of a network bootstrapped with
+// linear code address generation.
+func bootstrapped(chainID flow.ChainID) map[Name]flow.Address {
+	generator := flow.NewAddressGenerator(chainID)
+
+	return withServiceAccount(
+		generator.SetIndex(1).Address(),
+		generator.SetIndex(2).Address(),
+		generator.SetIndex(3).Address(),
+		generator.SetIndex(4).Address(),
+	)
+}
+
+func withServiceAccount(service, fungibleToken, flowToken, flowFees flow.Address) map[Name]flow.Address {
+	return map[Name]flow.Address{
+		FungibleToken:              fungibleToken,
+		FungibleTokenMetadataViews: fungibleToken,
+		FlowToken:                  flowToken,
+		FlowFees:                   flowFees,
+		FlowStorageFees:            service,
+		FlowServiceAccount:         service,
+		NonFungibleToken:           service,
+		MetadataViews:              service,
+		ViewResolver:               service,
+		FlowIDTableStaking:         service,
+		FlowEpoch:                  service,
+		FlowClusterQC:              service,
+		FlowDKG:                    service,
+		LockedTokens:               service,
+		FlowStakingCollection:      service,
+		StakingProxy:               service,
+	}
+}
+
+// ChainID returns the network of this registry.
+func (r *Registry) ChainID() flow.ChainID {
+	return r.chainID
+}
+
+// Address returns the address of a core contract.
+func (r *Registry) Address(name Name) (flow.Address, error) {
+	address, ok := r.addresses[name]
+	if !ok {
+		return flow.EmptyAddress, fmt.Errorf("%w: %s", ErrUnknownContract, name)
+	}
+
+	return address, nil
+}
+
+// Names returns the names of all core contracts in the registry, sorted alphabetically.
+func (r *Registry) Names() []Name {
+	names := make([]Name, 0, len(r.addresses))
+	for name := range r.addresses {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+// Aliases returns the core contract addresses as import aliases.
+func (r *Registry) Aliases() imports.Aliases {
+	aliases := make(imports.Aliases, len(r.addresses))
+	for name, address := range r.addresses {
+		aliases[string(name)] = address
+	}
+
+	return aliases
+}
+
+// Address returns the address of a core contract on the given network.
+func Address(chainID flow.ChainID, name Name) (flow.Address, error) {
+	registry, err := ForChain(chainID)
+	if err != nil {
+		return flow.EmptyAddress, err
+	}
+
+	return registry.Address(name)
+}
+
+// Resolver returns an import resolver for the core contracts on all networks.
+func Resolver() *imports.Resolver {
+	aliases := make(map[flow.ChainID]imports.Aliases)
+	for _, chainID := range chainIDs {
+		registry, _ := ForChain(chainID)
+		aliases[chainID] = registry.Aliases()
+	}
+
+	return imports.NewResolver(aliases)
+}
+
+var chainIDs = []flow.ChainID{
+	flow.Mainnet,
+	flow.Testnet,
+	flow.Sandboxnet,
+	flow.Benchnet,
+	flow.Localnet,
+	flow.Emulator,
+	flow.BftTestnet,
+	flow.MonotonicEmulator,
+}
@@ -0,0 +1,120 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+)
+
+func TestForChain(t *testing.T) {
+	tests := []struct {
+		chainID   flow.ChainID
+		addresses map[contracts.Name]string
+	}{
+		{
+			chainID: flow.Mainnet,
+			addresses: map[contracts.Name]string{
+				contracts.FungibleToken:      "f233dcee88fe0abe",
+				contracts.FlowToken:          "1654653399040a61",
+				contracts.FlowFees:           "f919ee77447b7497",
+				contracts.FlowStorageFees:    "e467b9dd11fa00df",
+				contracts.NonFungibleToken:   "1d7e57aa55817448",
+				contracts.FlowIDTableStaking: "8624b52f9ddcd04a",
+				contracts.LockedTokens:       "8d0e87b65159ae63",
+			},
+		},
+		{
+			chainID: flow.Testnet,
+			addresses: map[contracts.Name]string{
+				contracts.FungibleToken:      "9a0766d93b6608b7",
+				contracts.FlowToken:          "7e60df042a9c0868",
+				contracts.FlowFees:           "912d5440f7e3769e",
+				contracts.FlowStorageFees:    "8c5303eaa26202d6",
+				contracts.NonFungibleToken:   "631e88ae7f1d7c20",
+				contracts.FlowIDTableStaking: "9eca2b38b18b5dfe",
+				contracts.StakingProxy:       "7aad92e5a0715d21",
+			},
+		},
+		{
+			chainID: flow.Emulator,
+			addresses: map[contracts.Name]string{
+				contracts.FungibleToken:      "ee82856bf20e2aa6",
+				contracts.FlowToken:          "0ae53cb6e3f42a79",
+				contracts.FlowFees:           "e5a8b7f23e8b548f",
+				contracts.FlowServiceAccount: "f8d6e0586b0a20c7",
+				contracts.NonFungibleToken:   "f8d6e0586b0a20c7",
+			},
+		},
+		{
+			chainID: flow.MonotonicEmulator,
+			addresses: map[contracts.Name]string{
+				contracts.FungibleToken:      "02",
+				contracts.FlowToken:          "03",
+				contracts.FlowServiceAccount: "01",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.chainID), func(t *testing.T) {
+			registry, err := contracts.ForChain(test.chainID)
+			require.NoError(t, err)
+			assert.Equal(t, test.chainID, registry.ChainID())
+
+			for name, expected := range test.addresses {
+				address, err := registry.Address(name)
+				require.NoError(t, err)
+				assert.Equal(t, flow.HexToAddress(expected), address, name)
+			}
+		})
+	}
+
+	t.Run("All chains", func(t *testing.T) {
+		for _, chainID := range []flow.ChainID{
+			flow.Mainnet, flow.Testnet, flow.Sandboxnet, flow.Benchnet,
+			flow.Localnet, flow.Emulator, flow.BftTestnet, flow.MonotonicEmulator,
+		} {
+			registry, err := contracts.ForChain(chainID)
+			require.NoError(t, err, chainID)
+			assert.Len(t, registry.Names(), 16)
+		}
+	})
+
+	t.Run("Unsupported chain", func(t *testing.T) {
+		_, err := contracts.ForChain("flow-unknown")
+		assert.ErrorIs(t, err, contracts.ErrUnsupportedChain)
+	})
+
+	t.Run("Unknown contract", func(t *testing.T) {
+		_, err := contracts.Address(flow.Emulator, "Foo")
+		assert.ErrorIs(t, err, contracts.ErrUnknownContract)
+	})
+}
+
+func TestResolver(t *testing.T) {
+	resolved, err := contracts.Resolver().Resolve(flow.Emulator, []byte("import \"FlowToken\"\npub fun main() {}"))
+	require.NoError(t, err)
+	assert.Equal(t, "import FlowToken from 0x0ae53cb6e3f42a79\npub fun main() {}", string(resolved))
+}
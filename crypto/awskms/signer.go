@@ -28,11 +28,13 @@ import (
 	"github.com/onflow/flow-go-sdk/crypto/internal"
 )
 
-var _ crypto.Signer = (*Signer)(nil)
+var (
+	_ crypto.ContextSigner = (*Signer)(nil)
+	_ crypto.HashingSigner = (*Signer)(nil)
+)
 
-// Signer is a AWS KMS implementation of crypto.Signer.
+// Signer is a AWS KMS implementation of crypto.Signer and crypto.ContextSigner.
 type Signer struct {
-	ctx    context.Context
 	client *kms.Client
 	key    Key
 	// ECDSA is the only algorithm supported by this package. The signature algorithm
@@ -47,6 +49,9 @@ type Signer struct {
 // SignerForKey returns a new AWS KMS signer for an asymmetric signing key version.
 //
 // Only ECDSA keys on P-256 and secp256k1 curves and SHA2-256 are supported.
+//
+// The context is only used to fetch the public key. Signing requests are made with the
+// context passed to SignWithContext, or with a background context by Sign.
 func (c *Client) SignerForKey(
 	ctx context.Context,
 	key Key,
@@ -57,7 +62,6 @@ func (c *Client) SignerForKey(
 	}
 
 	return &Signer{
-		ctx:       ctx,
 		client:    c.client,
 		key:       key,
 		curve:     pk.Algorithm(),
@@ -68,8 +72,17 @@ func (c *Client) SignerForKey(
 
 // Sign signs the given message using the KMS signing key for this signer.
 //
-// Reference: https://github.com/aws/aws-sdk-go-v2/blob/main/service/kms/api_op_Sign.go
+// The request to KMS is made with a background context. Use SignWithContext to bound
+// or cancel the request.
 func (s *Signer) Sign(message []byte) ([]byte, error) {
+	return s.SignWithContext(context.Background(), message)
+}
+
+// SignWithContext signs the given message using the KMS signing key for this signer,
+// making the request to KMS with the given context.
+//
+// Reference: https://github.com/aws/aws-sdk-go-v2/blob/main/service/kms/api_op_Sign.go
+func (s *Signer) SignWithContext(ctx context.Context, message []byte) ([]byte, error) {
 
 	keyArn := s.key.ARN()
 	// AWS KMS supports signing messages without pre-hashing
@@ -99,7 +112,7 @@ func (s *Signer) Sign(message []byte) ([]byte, error) {
 			MessageType:      types.MessageTypeDigest,
 		}
 	}
-	result, err := s.client.Sign(s.ctx, request)
+	result, err := s.client.Sign(ctx, request)
 	if err != nil {
 		return nil, fmt.Errorf("awskms: failed to sign: %w", err)
 	}
@@ -113,3 +126,8 @@ func (s *Signer) Sign(message []byte) ([]byte, error) {
 func (s *Signer) PublicKey() crypto.PublicKey {
 	return s.publicKey
 }
+
+// HashAlgorithm returns the hash algorithm associated with the KMS signing key.
+func (s *Signer) HashAlgorithm() crypto.HashAlgorithm {
+	return s.hashAlgo
+}
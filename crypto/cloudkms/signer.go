@@ -30,11 +30,13 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-var _ crypto.Signer = (*Signer)(nil)
+var (
+	_ crypto.ContextSigner = (*Signer)(nil)
+	_ crypto.HashingSigner = (*Signer)(nil)
+)
 
-// Signer is a Google Cloud KMS implementation of crypto.Signer.
+// Signer is a Google Cloud KMS implementation of crypto.Signer and crypto.ContextSigner.
 type Signer struct {
-	ctx    context.Context
 	client *kms.KeyManagementClient
 	key    Key
 	// ECDSA is the only algorithm supported by this package. The signature algorithm
@@ -49,6 +51,9 @@ type Signer struct {
 // SignerForKey returns a new Google Cloud KMS signer for an asymmetric signing key version.
 //
 // Only ECDSA keys on P-256 and secp256k1 curves and SHA2-256 are supported.
+//
+// The context is only used to fetch the public key. Signing requests are made with the
+// context passed to SignWithContext, or with a background context by Sign.
 func (c *Client) SignerForKey(
 	ctx context.Context,
 	key Key,
@@ -59,7 +64,6 @@ func (c *Client) SignerForKey(
 	}
 
 	return &Signer{
-		ctx:       ctx,
 		client:    c.client,
 		key:       key,
 		curve:     pk.Algorithm(),
@@ -70,8 +74,17 @@ func (c *Client) SignerForKey(
 
 // Sign signs the given message using the KMS signing key for this signer.
 //
-// Reference: https://cloud.google.com/kms/docs/create-validate-signatures
+// The request to KMS is made with a background context. Use SignWithContext to bound
+// or cancel the request.
 func (s *Signer) Sign(message []byte) ([]byte, error) {
+	return s.SignWithContext(context.Background(), message)
+}
+
+// SignWithContext signs the given message using the KMS signing key for this signer,
+// making the request to KMS with the given context.
+//
+// Reference: https://cloud.google.com/kms/docs/create-validate-signatures
+func (s *Signer) SignWithContext(ctx context.Context, message []byte) ([]byte, error) {
 
 	// Google KMS supports signing messages without pre-hashing
 	// up to 65536 bytes. Beyond that limit, messages must be
@@ -100,7 +113,7 @@ func (s *Signer) Sign(message []byte) ([]byte, error) {
 			DigestCrc32C: checksum(hash),
 		}
 	}
-	result, err := s.client.AsymmetricSign(s.ctx, request)
+	result, err := s.client.AsymmetricSign(ctx, request)
 	if err != nil {
 		return nil, fmt.Errorf("cloudkms: failed to sign: %w", err)
 	}
@@ -123,6 +136,11 @@ func (s *Signer) PublicKey() crypto.PublicKey {
 	return s.publicKey
 }
 
+// HashAlgorithm returns the hash algorithm associated with the KMS signing key.
+func (s *Signer) HashAlgorithm() crypto.HashAlgorithm {
+	return s.hashAlgo
+}
+
 // returns the Digest structure for the hashing algoroithm and hash value, required by the
 // signing prehash request
 // This function only covers algorithms supported by KMS. It should be extended
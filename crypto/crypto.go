@@ -19,6 +19,7 @@
 package crypto
 
 import (
+	"context"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/hex"
@@ -80,6 +81,42 @@ type Signer interface {
 	PublicKey() PublicKey
 }
 
+// A ContextSigner is a signer that accepts a context for each signature.
+//
+// Signers backed by remote services, such as key management systems, implement this interface
+// so that callers can cancel a signing request or set a deadline for it.
+type ContextSigner interface {
+	Signer
+	// SignWithContext signs the given message with this signer, using the given context
+	// for any remote request.
+	SignWithContext(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// A HashingSigner is a signer that reports the hash algorithm it signs with.
+//
+// This allows callers to match a signer to an account key without producing a signature.
+type HashingSigner interface {
+	Signer
+	// HashAlgorithm returns the hash algorithm used to hash messages before signing.
+	HashAlgorithm() HashAlgorithm
+}
+
+// SignWithContext signs the given message with the signer.
+//
+// If the signer is a ContextSigner, the context is passed to the signer. Otherwise, the message
+// is only signed if the context is not done yet.
+func SignWithContext(ctx context.Context, signer Signer, message []byte) ([]byte, error) {
+	if contextSigner, ok := signer.(ContextSigner); ok {
+		return contextSigner.SignWithContext(ctx, message)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return signer.Sign(message)
+}
+
 // An InMemorySigner is a signer that generates signatures using an in-memory private key.
 //
 // InMemorySigner implements simple signing that does not protect the private key against
@@ -92,7 +129,7 @@ type InMemorySigner struct {
 	Hasher     Hasher
 }
 
-var _ Signer = (*InMemorySigner)(nil)
+var _ HashingSigner = (*InMemorySigner)(nil)
 
 // NewInMemorySigner initializes and returns a new in-memory signer with the provided private key
 // and hashing algorithm.
@@ -122,6 +159,15 @@ func (s InMemorySigner) PublicKey() PublicKey {
 	return s.PrivateKey.PublicKey()
 }
 
+// HashAlgorithm returns the algorithm of the hasher of this signer.
+func (s InMemorySigner) HashAlgorithm() HashAlgorithm {
+	if s.Hasher == nil {
+		return UnknownHashAlgorithm
+	}
+
+	return s.Hasher.Algorithm()
+}
+
 // NaiveSigner is an alias for InMemorySigner.
 type NaiveSigner = InMemorySigner
 
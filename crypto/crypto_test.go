@@ -19,6 +19,7 @@
 package crypto_test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -31,6 +32,7 @@ import (
 
 	fgcrypto "github.com/onflow/crypto"
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
 )
 
 func TestGeneratePrivateKey(t *testing.T) {
@@ -186,3 +188,41 @@ hbOhaN5CWXjw0HQAZeXqjoswiWlVH0baBuwAPwFcdk5fG/KW60QvOYPExA==
 		assert.Equal(t, expected[key], pk.String())
 	})
 }
+
+type contextSigner struct {
+	crypto.Signer
+	ctx context.Context
+}
+
+func (s *contextSigner) SignWithContext(ctx context.Context, message []byte) ([]byte, error) {
+	s.ctx = ctx
+	return s.Signer.Sign(message)
+}
+
+func TestSignWithContext(t *testing.T) {
+	_, signer := test.AccountKeyGenerator().NewWithSigner()
+
+	t.Run("Signer", func(t *testing.T) {
+		sig, err := crypto.SignWithContext(context.Background(), signer, []byte("message"))
+		require.NoError(t, err)
+		assert.NotEmpty(t, sig)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = crypto.SignWithContext(ctx, signer, []byte("message"))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Context signer", func(t *testing.T) {
+		ctxSigner := &contextSigner{Signer: signer}
+
+		type key struct{}
+		ctx := context.WithValue(context.Background(), key{}, "value")
+
+		sig, err := crypto.SignWithContext(ctx, ctxSigner, []byte("message"))
+		require.NoError(t, err)
+		assert.NotEmpty(t, sig)
+		assert.Equal(t, ctx, ctxSigner.ctx)
+	})
+}
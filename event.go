@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/crypto/hash"
 	"github.com/onflow/flow-go-sdk/crypto"
 )
@@ -37,6 +38,10 @@ const (
 	EventAccountContractRemoved string = "flow.AccountContractRemoved"
 )
 
+// An Event is emitted by a transaction.
+//
+// The event data should be read with DecodeValue, which returns it regardless of whether the
+// event was fetched with lazy event decoding.
 type Event struct {
 	// Type is the qualified event type.
 	Type string
@@ -46,12 +51,47 @@ type Event struct {
 	TransactionIndex int
 	// EventIndex is the index of the event within the transaction it was emitted from.
 	EventIndex int
-	// Value contains the event data.
+	// Value caches the decoded event data.
+	//
+	// Value is zero if the event was fetched with lazy event decoding, until DecodeValue
+	// is called. Use DecodeValue rather than reading Value directly.
 	Value cadence.Event
 	// Bytes representing event data.
 	Payload []byte
 }
 
+// DecodeValue returns the event data, decoding it from the JSON-CDC payload on first access.
+//
+// This is the primary way to read event data, as it works for both eagerly and lazily decoded events.
+//
+// The decoded value is stored in Value, so later calls do not decode the payload again.
+// If no options are given, the payload is decoded with the default options of the access
+// clients. Events fetched by a client configured with SetJSONOptions must be decoded with
+// the same options.
+func (e *Event) DecodeValue(options ...jsoncdc.Option) (cadence.Event, error) {
+	if e.Value.EventType != nil || len(e.Payload) == 0 {
+		return e.Value, nil
+	}
+
+	if len(options) == 0 {
+		options = []jsoncdc.Option{jsoncdc.WithAllowUnstructuredStaticTypes(true)}
+	}
+
+	value, err := jsoncdc.Decode(nil, e.Payload, options...)
+	if err != nil {
+		return cadence.Event{}, fmt.Errorf("failed to decode event payload: %w", err)
+	}
+
+	eventValue, isEvent := value.(cadence.Event)
+	if !isEvent {
+		return cadence.Event{}, fmt.Errorf("failed to decode event payload: expected Event value, got %T", value)
+	}
+
+	e.Value = eventValue
+
+	return e.Value, nil
+}
+
 // String returns the string representation of this event.
 func (e Event) String() string {
 	return fmt.Sprintf("%s: %s", e.Type, e.ID())
@@ -64,32 +104,29 @@ func (e Event) ID() string {
 
 // Encode returns the canonical RLP byte representation of this event.
 func (e Event) Encode() []byte {
-	temp := struct {
-		TransactionID []byte
-		EventIndex    uint
-	}{
-		TransactionID: e.TransactionID[:],
-		EventIndex:    uint(e.EventIndex),
-	}
-	return mustRLPEncode(&temp)
+	contentSize := rlpBytesSize(e.TransactionID[:]) + rlpUintSize(uint64(e.EventIndex))
+
+	buf := make([]byte, 0, rlpListSize(contentSize))
+	buf = rlpAppendListHeader(buf, contentSize)
+	buf = rlpAppendBytes(buf, e.TransactionID[:])
+	return rlpAppendUint(buf, uint64(e.EventIndex))
 }
 
 // Fingerprint calculates a fingerprint of an event.
 func (e *Event) Fingerprint() []byte {
-
-	return mustRLPEncode(struct {
-		TxID             []byte
-		Index            uint32
-		Type             string
-		TransactionIndex uint32
-		Payload          []byte
-	}{
-		TxID:             e.TransactionID[:],
-		Index:            uint32(e.EventIndex),
-		Type:             string(e.Type),
-		TransactionIndex: uint32(e.TransactionIndex),
-		Payload:          e.Payload[:],
-	})
+	contentSize := rlpBytesSize(e.TransactionID[:]) +
+		rlpUintSize(uint64(uint32(e.EventIndex))) +
+		rlpStringSize(e.Type) +
+		rlpUintSize(uint64(uint32(e.TransactionIndex))) +
+		rlpBytesSize(e.Payload)
+
+	buf := make([]byte, 0, rlpListSize(contentSize))
+	buf = rlpAppendListHeader(buf, contentSize)
+	buf = rlpAppendBytes(buf, e.TransactionID[:])
+	buf = rlpAppendUint(buf, uint64(uint32(e.EventIndex)))
+	buf = rlpAppendString(buf, e.Type)
+	buf = rlpAppendUint(buf, uint64(uint32(e.TransactionIndex)))
+	return rlpAppendBytes(buf, e.Payload)
 }
 
 // BlockEvents are the events that occurred in a specific block.
@@ -124,6 +161,14 @@ func CalculateEventsHash(es []Event) (crypto.Hash, error) {
 type AccountCreatedEvent Event
 
 // Address returns the address of the newly-created account.
+//
+// The event payload is decoded if the event was fetched with lazy event decoding.
 func (evt AccountCreatedEvent) Address() Address {
-	return BytesToAddress(evt.Value.Fields[0].(cadence.Address).Bytes())
+	e := Event(evt)
+	value, err := e.DecodeValue()
+	if err != nil {
+		panic(err)
+	}
+
+	return BytesToAddress(value.Fields[0].(cadence.Address).Bytes())
 }
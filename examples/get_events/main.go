@@ -65,8 +65,11 @@ func printEvents(result []flow.BlockEvents, err error) {
 
 func printEvent(events []flow.Event) {
 	for _, event := range events {
+		value, err := event.DecodeValue()
+		examples.Handle(err)
+
 		fmt.Printf("\n\nType: %s", event.Type)
-		fmt.Printf("\nValues: %v", event.Value)
+		fmt.Printf("\nValues: %v", value)
 		fmt.Printf("\nTransaction ID: %s", event.TransactionID)
 	}
 }
@@ -75,12 +75,12 @@ func SinglePartyMultiSignatureDemo() {
 		SetPayer(account1.Address).
 		AddAuthorizer(account1.Address)
 
-	// account 1 signs the envelope with key 1
-	err = tx.SignEnvelope(account1.Address, account1.Keys[0].Index, key1Signer)
+	// select the keys of account 1 that reach the weight threshold, i.e. key 1 and key 2
+	plan, err := flow.PlanSigningKeys(account1, flow.NewKeySigners(key1Signer, key2Signer))
 	examples.Handle(err)
 
-	// account 1 signs the envelope with key 2
-	err = tx.SignEnvelope(account1.Address, account1.Keys[1].Index, key2Signer)
+	// account 1 signs the envelope with the selected keys
+	err = plan.SignTransaction(tx)
 	examples.Handle(err)
 
 	err = flowClient.SendTransaction(ctx, *tx)
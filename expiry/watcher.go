@@ -0,0 +1,287 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package expiry tracks submitted transactions against the chain head and flags
+// them once they can no longer be included in a block.
+//
+// A transaction that references a block which is too far behind the finalized chain
+// head is never executed. Tracking the expiry height locally allows such transactions
+// to be rebuilt with a fresh reference block and resent, instead of waiting for a result
+// that never arrives.
+package expiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// DefaultPollInterval is the default interval between chain head requests.
+const DefaultPollInterval = time.Second
+
+// ErrNotSigned is returned when watching a transaction that has no envelope signature.
+//
+// Transactions are tracked by ID, which covers their signatures, so only a fully signed
+// transaction has the ID it is submitted and later forgotten under.
+var ErrNotSigned = errors.New("expiry: transaction is not fully signed")
+
+// Client is the subset of the access API used by the Watcher.
+type Client interface {
+	GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error)
+	GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error)
+}
+
+// An ExpiredTransaction is a watched transaction that is considered expired.
+type ExpiredTransaction struct {
+	Transaction  *flow.Transaction
+	ExpiryHeight uint64
+	// Height is the chain head height at which the transaction was flagged.
+	Height uint64
+}
+
+// A Watcher flags watched transactions once the finalized chain head reaches their expiry height.
+//
+// A Watcher is safe for concurrent use.
+type Watcher struct {
+	client       Client
+	pollInterval time.Duration
+	margin       uint64
+	onExpired    func(ExpiredTransaction)
+
+	mu      sync.Mutex
+	head    uint64
+	watched map[flow.Identifier]watchedTransaction
+	// heights caches the heights of reference blocks, which are usually shared by many transactions
+	heights map[flow.Identifier]uint64
+}
+
+type watchedTransaction struct {
+	tx           *flow.Transaction
+	expiryHeight uint64
+}
+
+// An Option configures a Watcher.
+type Option func(*Watcher)
+
+// WithPollInterval sets the interval between chain head requests.
+func WithPollInterval(interval time.Duration) Option {
+	return func(w *Watcher) {
+		w.pollInterval = interval
+	}
+}
+
+// WithMargin flags transactions the given number of blocks before they actually expire,
+// leaving time to rebuild and resend them.
+func WithMargin(blocks uint64) Option {
+	return func(w *Watcher) {
+		w.margin = blocks
+	}
+}
+
+// WithExpiredHandler sets a function that is called for every transaction flagged as expired.
+func WithExpiredHandler(handler func(ExpiredTransaction)) Option {
+	return func(w *Watcher) {
+		w.onExpired = handler
+	}
+}
+
+// NewWatcher creates a new expiry watcher.
+func NewWatcher(client Client, opts ...Option) *Watcher {
+	w := &Watcher{
+		client:       client,
+		pollInterval: DefaultPollInterval,
+		watched:      make(map[flow.Identifier]watchedTransaction),
+		heights:      make(map[flow.Identifier]uint64),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Watch starts tracking a transaction, fetching the header of its reference block if it is not known yet.
+//
+// The transaction must be fully signed, and is tracked under the ID it is submitted with.
+// The expiry height of the transaction is returned.
+func (w *Watcher) Watch(ctx context.Context, tx *flow.Transaction) (uint64, error) {
+	if len(tx.EnvelopeSignatures) == 0 {
+		return 0, ErrNotSigned
+	}
+
+	w.mu.Lock()
+	height, ok := w.heights[tx.ReferenceBlockID]
+	w.mu.Unlock()
+
+	if ok {
+		return w.WatchWithReference(tx, &flow.BlockHeader{ID: tx.ReferenceBlockID, Height: height})
+	}
+
+	header, err := w.client.GetBlockHeaderByID(ctx, tx.ReferenceBlockID)
+	if err != nil {
+		return 0, fmt.Errorf("expiry: failed to fetch reference block %s: %w", tx.ReferenceBlockID, err)
+	}
+
+	return w.WatchWithReference(tx, header)
+}
+
+// WatchWithReference starts tracking a transaction using the provided header of its reference block.
+//
+// The transaction must be fully signed, and is tracked under the ID it is submitted with.
+// The expiry height of the transaction is returned.
+func (w *Watcher) WatchWithReference(tx *flow.Transaction, referenceBlock *flow.BlockHeader) (uint64, error) {
+	if len(tx.EnvelopeSignatures) == 0 {
+		return 0, ErrNotSigned
+	}
+
+	expiryHeight, err := tx.ExpiryHeight(referenceBlock)
+	if err != nil {
+		return 0, fmt.Errorf("expiry: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.heights[referenceBlock.ID] = referenceBlock.Height
+	w.watched[tx.ID()] = watchedTransaction{
+		tx:           tx,
+		expiryHeight: expiryHeight,
+	}
+
+	return expiryHeight, nil
+}
+
+// Forget stops tracking a transaction, for example once it has been sealed.
+func (w *Watcher) Forget(txID flow.Identifier) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.watched, txID)
+}
+
+// Len returns the number of tracked transactions.
+func (w *Watcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.watched)
+}
+
+// Head returns the latest chain head height seen by the watcher.
+func (w *Watcher) Head() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.head
+}
+
+// Remaining returns the number of blocks that can still include the transaction,
+// based on the latest chain head seen by the watcher.
+//
+// False is returned if the transaction is not tracked.
+func (w *Watcher) Remaining(txID flow.Identifier) (uint64, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	watched, ok := w.watched[txID]
+	if !ok {
+		return 0, false
+	}
+
+	if w.head >= watched.expiryHeight {
+		return 0, true
+	}
+
+	return watched.expiryHeight - w.head, true
+}
+
+// Advance moves the chain head to the given height and returns the transactions that are
+// considered expired at that height.
+//
+// Expired transactions are no longer tracked and are passed to the expired handler, if any.
+func (w *Watcher) Advance(height uint64) []ExpiredTransaction {
+	w.mu.Lock()
+
+	if height > w.head {
+		w.head = height
+	}
+
+	var expired []ExpiredTransaction
+	for id, watched := range w.watched {
+		if w.head+w.margin <= watched.expiryHeight {
+			continue
+		}
+
+		expired = append(expired, ExpiredTransaction{
+			Transaction:  watched.tx,
+			ExpiryHeight: watched.expiryHeight,
+			Height:       w.head,
+		})
+		delete(w.watched, id)
+	}
+
+	// reference blocks that expired are not needed anymore
+	for id, refHeight := range w.heights {
+		if refHeight+flow.DefaultTransactionExpiry < w.head {
+			delete(w.heights, id)
+		}
+	}
+
+	w.mu.Unlock()
+
+	if w.onExpired != nil {
+		for _, e := range expired {
+			w.onExpired(e)
+		}
+	}
+
+	return expired
+}
+
+// Poll fetches the finalized chain head and advances the watcher to it.
+func (w *Watcher) Poll(ctx context.Context) ([]ExpiredTransaction, error) {
+	header, err := w.client.GetLatestBlockHeader(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("expiry: failed to fetch latest block header: %w", err)
+	}
+
+	return w.Advance(header.Height), nil
+}
+
+// Run polls the chain head until the context is cancelled.
+//
+// Errors fetching the chain head are ignored; the watcher catches up on the next successful poll.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = w.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expiry_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/expiry"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	_ expiry.Client = (*grpc.Client)(nil)
+	_ expiry.Client = (*http.Client)(nil)
+)
+
+type mockClient struct {
+	mu      sync.Mutex
+	head    uint64
+	headers map[flow.Identifier]flow.BlockHeader
+	lookups int
+}
+
+func (c *mockClient) GetLatestBlockHeader(_ context.Context, _ bool) (*flow.BlockHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &flow.BlockHeader{Height: c.head}, nil
+}
+
+func (c *mockClient) GetBlockHeaderByID(_ context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lookups++
+	header, ok := c.headers[blockID]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	return &header, nil
+}
+
+func (c *mockClient) setHead(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.head = height
+}
+
+func newTransaction(referenceBlock flow.BlockHeader, seq uint64) *flow.Transaction {
+	address := flow.ServiceAddress(flow.Emulator)
+
+	return flow.NewTransaction().
+		SetScript(test.GreetingScript).
+		SetReferenceBlockID(referenceBlock.ID).
+		SetProposalKey(address, 0, seq).
+		SetPayer(address).
+		AddEnvelopeSignature(address, 0, []byte{1})
+}
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	ref := test.BlockHeaderGenerator().New()
+
+	t.Run("Flags expired transactions", func(t *testing.T) {
+		client := &mockClient{headers: map[flow.Identifier]flow.BlockHeader{ref.ID: ref}}
+
+		var handled []expiry.ExpiredTransaction
+		w := expiry.NewWatcher(client, expiry.WithExpiredHandler(func(e expiry.ExpiredTransaction) {
+			handled = append(handled, e)
+		}))
+
+		txA := newTransaction(ref, 0)
+		txB := newTransaction(ref, 1)
+
+		expiryHeight, err := w.Watch(ctx, txA)
+		require.NoError(t, err)
+		assert.Equal(t, ref.Height+flow.DefaultTransactionExpiry, expiryHeight)
+
+		_, err = w.Watch(ctx, txB)
+		require.NoError(t, err)
+		assert.Equal(t, 1, client.lookups)
+		assert.Equal(t, 2, w.Len())
+
+		client.setHead(expiryHeight)
+		expired, err := w.Poll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+
+		remaining, ok := w.Remaining(txA.ID())
+		require.True(t, ok)
+		assert.Equal(t, uint64(0), remaining)
+
+		w.Forget(txB.ID())
+
+		client.setHead(expiryHeight + 1)
+		expired, err = w.Poll(ctx)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, txA, expired[0].Transaction)
+		assert.Equal(t, expiryHeight, expired[0].ExpiryHeight)
+		assert.Equal(t, expiryHeight+1, expired[0].Height)
+		assert.Equal(t, expired, handled)
+		assert.Equal(t, 0, w.Len())
+	})
+
+	t.Run("Margin", func(t *testing.T) {
+		w := expiry.NewWatcher(&mockClient{}, expiry.WithMargin(10))
+
+		tx := newTransaction(ref, 0)
+		expiryHeight, err := w.WatchWithReference(tx, &ref)
+		require.NoError(t, err)
+
+		assert.Empty(t, w.Advance(expiryHeight-10))
+
+		remaining, ok := w.Remaining(tx.ID())
+		require.True(t, ok)
+		assert.Equal(t, uint64(10), remaining)
+
+		assert.Len(t, w.Advance(expiryHeight-9), 1)
+	})
+
+	t.Run("Wrong reference block", func(t *testing.T) {
+		w := expiry.NewWatcher(&mockClient{})
+
+		other := ref
+		other.ID = flow.HexToID("ff")
+
+		_, err := w.WatchWithReference(newTransaction(ref, 0), &other)
+		assert.Error(t, err)
+	})
+
+	t.Run("Not signed", func(t *testing.T) {
+		client := &mockClient{headers: map[flow.Identifier]flow.BlockHeader{ref.ID: ref}}
+		w := expiry.NewWatcher(client)
+
+		tx := newTransaction(ref, 0)
+		tx.EnvelopeSignatures = nil
+
+		_, err := w.Watch(ctx, tx)
+		assert.ErrorIs(t, err, expiry.ErrNotSigned)
+
+		_, err = w.WatchWithReference(tx, &ref)
+		assert.ErrorIs(t, err, expiry.ErrNotSigned)
+		assert.Equal(t, 0, w.Len())
+	})
+
+	t.Run("Unknown reference block", func(t *testing.T) {
+		w := expiry.NewWatcher(&mockClient{})
+
+		_, err := w.Watch(ctx, newTransaction(ref, 0))
+		assert.Error(t, err)
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		client := &mockClient{}
+
+		expiredCh := make(chan expiry.ExpiredTransaction, 1)
+		w := expiry.NewWatcher(
+			client,
+			expiry.WithPollInterval(time.Millisecond),
+			expiry.WithExpiredHandler(func(e expiry.ExpiredTransaction) {
+				expiredCh <- e
+			}),
+		)
+
+		tx := newTransaction(ref, 0)
+		expiryHeight, err := w.WatchWithReference(tx, &ref)
+		require.NoError(t, err)
+
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go w.Run(runCtx)
+
+		client.setHead(expiryHeight + 1)
+
+		select {
+		case e := <-expiredCh:
+			assert.Equal(t, tx.ID(), e.Transaction.ID())
+		case <-time.After(time.Second):
+			t.Fatal("transaction was not flagged as expired")
+		}
+	})
+}
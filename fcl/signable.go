@@ -0,0 +1,151 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fcl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// ErrMessageMismatch is returned when the message of a signable does not match its voucher.
+var ErrMessageMismatch = errors.New("fcl: signable message does not match voucher")
+
+// A Signable is the object FCL sends to a wallet to request a transaction signature.
+//
+// Only the fields needed to sign the transaction are decoded.
+type Signable struct {
+	FType   string  `json:"f_type"`
+	FVsn    string  `json:"f_vsn"`
+	Message string  `json:"message"`
+	Addr    string  `json:"addr"`
+	KeyID   int     `json:"keyId"`
+	Roles   Roles   `json:"roles"`
+	Voucher Voucher `json:"voucher"`
+}
+
+// Roles are the roles of the signing account in a transaction.
+type Roles struct {
+	Proposer   bool `json:"proposer"`
+	Authorizer bool `json:"authorizer"`
+	Payer      bool `json:"payer"`
+	Param      bool `json:"param,omitempty"`
+}
+
+// A CompositeSignature is the signature a wallet returns to FCL.
+type CompositeSignature struct {
+	FType     string `json:"f_type"`
+	FVsn      string `json:"f_vsn"`
+	Addr      string `json:"addr"`
+	KeyID     int    `json:"keyId"`
+	Signature string `json:"signature"`
+}
+
+// SigningMessage returns the message the given account must sign for the transaction,
+// including the transaction domain tag.
+//
+// The payer signs the envelope message, all other accounts sign the payload message.
+func SigningMessage(tx *flow.Transaction, address flow.Address) []byte {
+	message := tx.PayloadMessage()
+	if address == tx.Payer {
+		message = tx.EnvelopeMessage()
+	}
+
+	return append(flow.TransactionDomainTag[:], message...)
+}
+
+// Transaction returns the transaction of the signable.
+func (s Signable) Transaction() (*flow.Transaction, error) {
+	return s.Voucher.Transaction()
+}
+
+// Verify checks that the message of the signable is the message the signing account must
+// sign for the voucher, and returns the message.
+//
+// Wallets must not sign the message of a signable without verifying it, since it is
+// otherwise not known which transaction is signed.
+func (s Signable) Verify() ([]byte, error) {
+	tx, err := s.Transaction()
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := hex.DecodeString(strings.TrimPrefix(s.Message, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("fcl: invalid signable message: %w", err)
+	}
+
+	expected := SigningMessage(tx, flow.HexToAddress(s.Addr))
+	if !bytes.Equal(message, expected) {
+		return nil, ErrMessageMismatch
+	}
+
+	return expected, nil
+}
+
+// Sign verifies the signable and signs its message with the given signer.
+func (s Signable) Sign(signer crypto.Signer) (CompositeSignature, error) {
+	message, err := s.Verify()
+	if err != nil {
+		return CompositeSignature{}, err
+	}
+
+	sig, err := signer.Sign(message)
+	if err != nil {
+		return CompositeSignature{}, fmt.Errorf("fcl: failed to sign: %w", err)
+	}
+
+	return CompositeSignature{
+		FType:     "CompositeSignature",
+		FVsn:      "1.0.0",
+		Addr:      formatAddress(flow.HexToAddress(s.Addr)),
+		KeyID:     s.KeyID,
+		Signature: hex.EncodeToString(sig),
+	}, nil
+}
+
+// NewSignable creates the signable for the given signing key of a transaction.
+func NewSignable(tx *flow.Transaction, address flow.Address, keyIndex int) Signable {
+	authorizer := false
+	for _, a := range tx.Authorizers {
+		if a == address {
+			authorizer = true
+			break
+		}
+	}
+
+	return Signable{
+		FType:   "Signable",
+		FVsn:    "1.0.1",
+		Message: hex.EncodeToString(SigningMessage(tx, address)),
+		Addr:    formatAddress(address),
+		KeyID:   keyIndex,
+		Roles: Roles{
+			Proposer:   address == tx.ProposalKey.Address,
+			Authorizer: authorizer,
+			Payer:      address == tx.Payer,
+		},
+		Voucher: VoucherFromTransaction(tx),
+	}
+}
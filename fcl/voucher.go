@@ -0,0 +1,165 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fcl converts between transactions and the JSON objects that FCL and wallets
+// exchange while signing transactions.
+package fcl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A Voucher is the FCL representation of a transaction.
+type Voucher struct {
+	Cadence      string            `json:"cadence"`
+	RefBlock     string            `json:"refBlock"`
+	ComputeLimit uint64            `json:"computeLimit"`
+	Arguments    []json.RawMessage `json:"arguments"`
+	ProposalKey  ProposalKey       `json:"proposalKey"`
+	Payer        string            `json:"payer"`
+	Authorizers  []string          `json:"authorizers"`
+	PayloadSigs  []Signature       `json:"payloadSigs"`
+	EnvelopeSigs []Signature       `json:"envelopeSigs"`
+}
+
+// A ProposalKey is the FCL representation of a transaction proposal key.
+type ProposalKey struct {
+	Address     string `json:"address"`
+	KeyID       int    `json:"keyId"`
+	SequenceNum uint64 `json:"sequenceNum"`
+}
+
+// A Signature is the FCL representation of a transaction signature.
+//
+// FCL lists the signers of a transaction before they have signed, in which case Sig is nil.
+type Signature struct {
+	Address string  `json:"address"`
+	KeyID   int     `json:"keyId"`
+	Sig     *string `json:"sig"`
+}
+
+// VoucherFromTransaction converts a transaction to a voucher.
+func VoucherFromTransaction(tx *flow.Transaction) Voucher {
+	arguments := make([]json.RawMessage, len(tx.Arguments))
+	for i, arg := range tx.Arguments {
+		arguments[i] = json.RawMessage(arg)
+	}
+
+	authorizers := make([]string, len(tx.Authorizers))
+	for i, authorizer := range tx.Authorizers {
+		authorizers[i] = formatAddress(authorizer)
+	}
+
+	return Voucher{
+		Cadence:      string(tx.Script),
+		RefBlock:     tx.ReferenceBlockID.String(),
+		ComputeLimit: tx.GasLimit,
+		Arguments:    arguments,
+		ProposalKey: ProposalKey{
+			Address:     formatAddress(tx.ProposalKey.Address),
+			KeyID:       tx.ProposalKey.KeyIndex,
+			SequenceNum: tx.ProposalKey.SequenceNumber,
+		},
+		Payer:        formatAddress(tx.Payer),
+		Authorizers:  authorizers,
+		PayloadSigs:  fromTransactionSignatures(tx.PayloadSignatures),
+		EnvelopeSigs: fromTransactionSignatures(tx.EnvelopeSignatures),
+	}
+}
+
+func fromTransactionSignatures(signatures []flow.TransactionSignature) []Signature {
+	sigs := make([]Signature, len(signatures))
+	for i, signature := range signatures {
+		sig := hex.EncodeToString(signature.Signature)
+		sigs[i] = Signature{
+			Address: formatAddress(signature.Address),
+			KeyID:   signature.KeyIndex,
+			Sig:     &sig,
+		}
+	}
+
+	return sigs
+}
+
+// Transaction converts the voucher to a transaction.
+//
+// The arguments are used as encoded by FCL, without normalization, so that the transaction
+// has the same payload as the one FCL builds. Signatures without a signature value are omitted.
+func (v Voucher) Transaction() (*flow.Transaction, error) {
+	refBlock, err := hex.DecodeString(strings.TrimPrefix(v.RefBlock, "0x"))
+	if err != nil || len(refBlock) != len(flow.EmptyID) {
+		return nil, fmt.Errorf("fcl: invalid reference block ID %q", v.RefBlock)
+	}
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(v.Cadence)).
+		SetReferenceBlockID(flow.BytesToID(refBlock)).
+		SetGasLimit(v.ComputeLimit).
+		SetProposalKey(flow.HexToAddress(v.ProposalKey.Address), v.ProposalKey.KeyID, v.ProposalKey.SequenceNum).
+		SetPayer(flow.HexToAddress(v.Payer))
+
+	for _, authorizer := range v.Authorizers {
+		tx.AddAuthorizer(flow.HexToAddress(authorizer))
+	}
+
+	for i, arg := range v.Arguments {
+		// FCL encodes arguments with JSON.stringify, which produces compact JSON
+		var compacted bytes.Buffer
+		err := json.Compact(&compacted, arg)
+		if err != nil {
+			return nil, fmt.Errorf("fcl: invalid argument at index %d: %w", i, err)
+		}
+		tx.Arguments = append(tx.Arguments, compacted.Bytes())
+	}
+
+	for _, sig := range v.PayloadSigs {
+		if sig.Sig == nil {
+			continue
+		}
+
+		signature, err := hex.DecodeString(strings.TrimPrefix(*sig.Sig, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("fcl: invalid payload signature of key %d of %s: %w", sig.KeyID, sig.Address, err)
+		}
+		tx.AddPayloadSignature(flow.HexToAddress(sig.Address), sig.KeyID, signature)
+	}
+
+	for _, sig := range v.EnvelopeSigs {
+		if sig.Sig == nil {
+			continue
+		}
+
+		signature, err := hex.DecodeString(strings.TrimPrefix(*sig.Sig, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("fcl: invalid envelope signature of key %d of %s: %w", sig.KeyID, sig.Address, err)
+		}
+		tx.AddEnvelopeSignature(flow.HexToAddress(sig.Address), sig.KeyID, signature)
+	}
+
+	return tx, nil
+}
+
+func formatAddress(address flow.Address) string {
+	return "0x" + address.Hex()
+}
@@ -0,0 +1,157 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fcl_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/fcl"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestVoucher(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+		require.NoError(t, tx.AddArgument(cadence.String("foo")))
+
+		b, err := json.Marshal(fcl.VoucherFromTransaction(tx))
+		require.NoError(t, err)
+
+		var voucher fcl.Voucher
+		require.NoError(t, json.Unmarshal(b, &voucher))
+
+		decoded, err := voucher.Transaction()
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID(), decoded.ID())
+		assert.Equal(t, tx.Arguments, decoded.Arguments)
+		assert.Equal(t, tx.PayloadSignatures, decoded.PayloadSignatures)
+		assert.Equal(t, tx.EnvelopeSignatures, decoded.EnvelopeSignatures)
+	})
+
+	t.Run("FCL voucher", func(t *testing.T) {
+		data := `{
+			"cadence": "transaction(msg: String) { execute { log(msg) } }",
+			"refBlock": "7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7",
+			"computeLimit": 100,
+			"arguments": [{"type": "String", "value": "foo"}],
+			"proposalKey": {"address": "0x01cf0e2f2f715450", "keyId": 1, "sequenceNum": 42},
+			"payer": "0x179b6b1cb6755e31",
+			"authorizers": ["0x01cf0e2f2f715450"],
+			"payloadSigs": [{"address": "0x01cf0e2f2f715450", "keyId": 1, "sig": null}],
+			"envelopeSigs": [{"address": "0x179b6b1cb6755e31", "keyId": 0, "sig": "0102"}]
+		}`
+
+		var voucher fcl.Voucher
+		require.NoError(t, json.Unmarshal([]byte(data), &voucher))
+
+		tx, err := voucher.Transaction()
+		require.NoError(t, err)
+
+		proposer := flow.HexToAddress("01cf0e2f2f715450")
+		payer := flow.HexToAddress("179b6b1cb6755e31")
+
+		assert.Equal(t, [][]byte{[]byte(`{"type":"String","value":"foo"}`)}, tx.Arguments)
+		assert.Equal(t, uint64(100), tx.GasLimit)
+		assert.Equal(t, flow.ProposalKey{Address: proposer, KeyIndex: 1, SequenceNumber: 42}, tx.ProposalKey)
+		assert.Equal(t, payer, tx.Payer)
+		assert.Equal(t, []flow.Address{proposer}, tx.Authorizers)
+		assert.Empty(t, tx.PayloadSignatures)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, []byte{1, 2}, tx.EnvelopeSignatures[0].Signature)
+	})
+
+	t.Run("Invalid reference block", func(t *testing.T) {
+		_, err := fcl.Voucher{RefBlock: "zz"}.Transaction()
+		assert.Error(t, err)
+	})
+}
+
+func TestSignable(t *testing.T) {
+	accounts := test.AccountGenerator()
+	proposer, payer := accounts.New(), accounts.New()
+
+	keys := test.AccountKeyGenerator()
+	proposerKey, proposerSigner := keys.NewWithSigner()
+	payerKey, payerSigner := keys.NewWithSigner()
+	proposer.Keys = []*flow.AccountKey{proposerKey}
+	payer.Keys = []*flow.AccountKey{payerKey}
+
+	tx := flow.NewTransaction().
+		SetScript(test.GreetingScript).
+		SetReferenceBlockID(test.IdentifierGenerator().New()).
+		SetProposalKey(proposer.Address, proposerKey.Index, proposerKey.SequenceNumber).
+		SetPayer(payer.Address).
+		AddAuthorizer(proposer.Address)
+
+	// round trip through JSON, as exchanged between FCL and the wallet
+	roundTrip := func(t *testing.T, signable fcl.Signable) fcl.Signable {
+		b, err := json.Marshal(signable)
+		require.NoError(t, err)
+
+		var decoded fcl.Signable
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		return decoded
+	}
+
+	signable := roundTrip(t, fcl.NewSignable(tx, proposer.Address, proposerKey.Index))
+	assert.Equal(t, fcl.Roles{Proposer: true, Authorizer: true}, signable.Roles)
+
+	compositeSig, err := signable.Sign(proposerSigner)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("0x%s", proposer.Address.Hex()), compositeSig.Addr)
+
+	sig, err := hex.DecodeString(compositeSig.Signature)
+	require.NoError(t, err)
+	tx.AddPayloadSignature(proposer.Address, compositeSig.KeyID, sig)
+
+	signable = roundTrip(t, fcl.NewSignable(tx, payer.Address, payerKey.Index))
+	assert.Equal(t, fcl.Roles{Payer: true}, signable.Roles)
+	assert.Equal(t, fcl.SigningMessage(tx, payer.Address), append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...))
+
+	compositeSig, err = signable.Sign(payerSigner)
+	require.NoError(t, err)
+
+	sig, err = hex.DecodeString(compositeSig.Signature)
+	require.NoError(t, err)
+	tx.AddEnvelopeSignature(payer.Address, compositeSig.KeyID, sig)
+
+	err = tx.VerifySignatures(func(address flow.Address) (*flow.Account, error) {
+		if address == proposer.Address {
+			return proposer, nil
+		}
+		return payer, nil
+	})
+	assert.NoError(t, err)
+
+	t.Run("Message mismatch", func(t *testing.T) {
+		signable := fcl.NewSignable(tx, payer.Address, payerKey.Index)
+		signable.Voucher.ComputeLimit = 1
+
+		_, err := signable.Sign(payerSigner)
+		assert.ErrorIs(t, err, fcl.ErrMessageMismatch)
+	})
+}
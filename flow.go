@@ -22,8 +22,6 @@ import (
 	"encoding/hex"
 	"sync"
 
-	"github.com/ethereum/go-ethereum/rlp"
-
 	"github.com/onflow/flow-go-sdk/crypto"
 )
 
@@ -127,55 +125,28 @@ func (id ChainID) String() string {
 }
 
 // entityHasher is a thread-safe hasher used to hash Flow entities.
+//
+// Hashers are not safe for concurrent use, so each call takes a hasher
+// from a pool rather than serializing all callers on a single instance.
 type entityHasher struct {
-	mut    sync.Mutex
-	hasher crypto.Hasher
-}
-
-func (h *entityHasher) ComputeHash(b []byte) crypto.Hash {
-	h.mut.Lock()
-	defer h.mut.Unlock()
-	return h.hasher.ComputeHash(b)
+	pool sync.Pool
 }
 
-// defaultEntityHasher is the default hasher used to compute Flow identifiers.
-var defaultEntityHasher *entityHasher
-
-func init() {
-	defaultEntityHasher = &entityHasher{
-		hasher: crypto.NewSHA3_256(),
+func newEntityHasher(newHasher func() crypto.Hasher) *entityHasher {
+	return &entityHasher{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return newHasher()
+			},
+		},
 	}
 }
 
-func rlpEncode(v interface{}) ([]byte, error) {
-	return rlp.EncodeToBytes(v)
-}
-
-func rlpDecode(b []byte, v interface{}) error {
-	return rlp.DecodeBytes(b, v)
-}
-
-func mustRLPEncode(v interface{}) []byte {
-	// Note(sideninja): This is a temporary workaround until cadence defines canonical format addressing the issue https://github.com/onflow/flow-go-sdk/issues/286
-	if tx, ok := v.(payloadCanonicalForm); ok {
-		for _, arg := range tx.Arguments {
-			if arg[len(arg)-1] == byte(10) {
-				arg = arg[:len(tx.Arguments)-1]
-			}
-		}
-	}
-
-	b, err := rlpEncode(v)
-	if err != nil {
-		panic(err)
-	}
-	return b
+func (h *entityHasher) ComputeHash(b []byte) crypto.Hash {
+	hasher := h.pool.Get().(crypto.Hasher)
+	defer h.pool.Put(hasher)
+	return hasher.ComputeHash(b)
 }
 
-func mustRLPDecode(b []byte, v interface{}) {
-	err := rlpDecode(b, v)
-	if err != nil {
-		panic(err)
-	}
-	return
-}
+// defaultEntityHasher is the default hasher used to compute Flow identifiers.
+var defaultEntityHasher = newEntityHasher(crypto.NewSHA3_256)
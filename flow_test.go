@@ -0,0 +1,92 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestEntityID_Concurrent(t *testing.T) {
+	tx := test.TransactionGenerator().New()
+	event := test.EventGenerator().New()
+	collection := test.CollectionGenerator().New()
+
+	txID, eventID, collectionID := tx.ID(), event.ID(), collection.ID()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Equal(t, txID, tx.ID())
+				assert.Equal(t, eventID, event.ID())
+				assert.Equal(t, collectionID, collection.ID())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkTransaction_ID(b *testing.B) {
+	tx := test.TransactionGenerator().New()
+
+	b.Run("Serial", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = tx.ID()
+		}
+	})
+
+	b.Run("Parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = tx.ID()
+			}
+		})
+	})
+}
+
+func BenchmarkEvent_ID(b *testing.B) {
+	event := test.EventGenerator().New()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = event.ID()
+		}
+	})
+}
+
+func BenchmarkCollection_ID(b *testing.B) {
+	collection := test.CollectionGenerator().New()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = collection.ID()
+		}
+	})
+}
@@ -0,0 +1,245 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package imports resolves the import declarations of Cadence scripts and transactions
+// to the addresses of the imported contracts on a given Flow network.
+package imports
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// Aliases maps contract names, import paths or placeholders to the address of the contract.
+type Aliases map[string]flow.Address
+
+// An UnresolvedImportError is returned when imports cannot be resolved for a network.
+type UnresolvedImportError struct {
+	ChainID flow.ChainID
+	Imports []string
+}
+
+func (e UnresolvedImportError) Error() string {
+	return fmt.Sprintf("unresolved imports on %s: %s", e.ChainID, strings.Join(e.Imports, ", "))
+}
+
+// A Resolver rewrites the imports of Cadence code to address imports.
+//
+// It supports the following forms of imports:
+//
+//	import "FlowToken"                                     // string import
+//	import FungibleToken from "./FungibleToken.cdc"        // path import
+//	import FungibleToken from 0xFUNGIBLETOKEN              // placeholder import
+//
+// String imports are resolved by contract name. Path imports are resolved by their full path,
+// or else by the file name without extension. Placeholder imports are resolved by the full
+// placeholder, or else by matching the placeholder against the contract names, ignoring case
+// and underscores. Address and built-in imports are left untouched.
+type Resolver struct {
+	aliases map[flow.ChainID]Aliases
+}
+
+// NewResolver returns a resolver with the given aliases per network.
+func NewResolver(aliases map[flow.ChainID]Aliases) *Resolver {
+	r := &Resolver{
+		aliases: make(map[flow.ChainID]Aliases),
+	}
+
+	for chainID, chainAliases := range aliases {
+		for name, address := range chainAliases {
+			r.AddAlias(chainID, name, address)
+		}
+	}
+
+	return r
+}
+
+// AddAlias adds an alias for a contract on the given network.
+func (r *Resolver) AddAlias(chainID flow.ChainID, name string, address flow.Address) *Resolver {
+	if r.aliases[chainID] == nil {
+		r.aliases[chainID] = make(Aliases)
+	}
+
+	r.aliases[chainID][name] = address
+	return r
+}
+
+// placeholderImport matches the location of imports from a placeholder address, such as 0xFUNGIBLETOKEN.
+//
+// Placeholders are not valid Cadence, so they are substituted before the code is parsed.
+var placeholderImport = regexp.MustCompile(`(?m)^(\s*import\s+[^\n]*?\bfrom\s+)(0x[0-9A-Za-z_]+)`)
+
+var hexAddress = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+
+// Resolve returns the code with all imports rewritten to address imports on the given network.
+//
+// An UnresolvedImportError listing every import without an alias is returned if not all
+// imports can be resolved.
+func (r *Resolver) Resolve(chainID flow.ChainID, code []byte) ([]byte, error) {
+	aliases := r.aliases[chainID]
+
+	var unresolved []string
+	addUnresolved := func(name string) {
+		for _, u := range unresolved {
+			if u == name {
+				return
+			}
+		}
+		unresolved = append(unresolved, name)
+	}
+
+	code = placeholderImport.ReplaceAllFunc(code, func(match []byte) []byte {
+		groups := placeholderImport.FindSubmatch(match)
+		prefix, placeholder := string(groups[1]), string(groups[2])
+
+		address, ok := aliases[placeholder]
+		if !ok {
+			if hexAddress.MatchString(placeholder) {
+				return match
+			}
+
+			address, ok = aliases.lookupPlaceholder(placeholder)
+			if !ok {
+				addUnresolved(placeholder)
+				return match
+			}
+		}
+
+		return []byte(prefix + formatAddress(address))
+	})
+
+	if len(unresolved) > 0 {
+		return nil, UnresolvedImportError{ChainID: chainID, Imports: unresolved}
+	}
+
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse imports: %w", err)
+	}
+
+	type replacement struct {
+		start, end int
+		text       string
+	}
+
+	var replacements []replacement
+	for _, declaration := range program.ImportDeclarations() {
+		location, ok := declaration.Location.(common.StringLocation)
+		if !ok {
+			continue
+		}
+
+		address, ok := aliases.lookupLocation(string(location))
+		if !ok {
+			addUnresolved(string(location))
+			continue
+		}
+
+		replacements = append(replacements, replacement{
+			start: declaration.StartPos.Offset,
+			end:   declaration.EndPos.Offset + 1,
+			text:  importDeclaration(declaration, string(location), address),
+		})
+	}
+
+	if len(unresolved) > 0 {
+		return nil, UnresolvedImportError{ChainID: chainID, Imports: unresolved}
+	}
+
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].start > replacements[j].start
+	})
+
+	resolved := append([]byte(nil), code...)
+	for _, rep := range replacements {
+		resolved = append(resolved[:rep.start], append([]byte(rep.text), resolved[rep.end:]...)...)
+	}
+
+	return resolved, nil
+}
+
+// ResolveTransaction rewrites the imports of the transaction script for the given network.
+func (r *Resolver) ResolveTransaction(chainID flow.ChainID, tx *flow.Transaction) error {
+	script, err := r.Resolve(chainID, tx.Script)
+	if err != nil {
+		return err
+	}
+
+	tx.SetScript(script)
+	return nil
+}
+
+// lookupLocation resolves a string import by its full location, or by its file name.
+func (a Aliases) lookupLocation(location string) (flow.Address, bool) {
+	if address, ok := a[location]; ok {
+		return address, true
+	}
+
+	address, ok := a[contractName(location)]
+	return address, ok
+}
+
+// lookupPlaceholder resolves a placeholder such as 0xFUNGIBLETOKEN or 0xFUNGIBLE_TOKEN to
+// the address of the contract with a matching name.
+func (a Aliases) lookupPlaceholder(placeholder string) (flow.Address, bool) {
+	normalized := normalizePlaceholder(strings.TrimPrefix(placeholder, "0x"))
+
+	for name, address := range a {
+		if normalizePlaceholder(name) == normalized {
+			return address, true
+		}
+	}
+
+	return flow.EmptyAddress, false
+}
+
+func normalizePlaceholder(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "_", ""))
+}
+
+// contractName returns the contract name of a string import, which is the file name of a path
+// without extension.
+func contractName(location string) string {
+	return strings.TrimSuffix(path.Base(location), path.Ext(location))
+}
+
+func importDeclaration(declaration *ast.ImportDeclaration, location string, address flow.Address) string {
+	identifiers := make([]string, len(declaration.Identifiers))
+	for i, identifier := range declaration.Identifiers {
+		identifiers[i] = identifier.Identifier
+	}
+
+	if len(identifiers) == 0 {
+		identifiers = []string{contractName(location)}
+	}
+
+	return fmt.Sprintf("import %s from %s", strings.Join(identifiers, ", "), formatAddress(address))
+}
+
+func formatAddress(address flow.Address) string {
+	return "0x" + address.Hex()
+}
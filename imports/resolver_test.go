@@ -0,0 +1,123 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imports_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+func newResolver() *imports.Resolver {
+	return imports.NewResolver(map[flow.ChainID]imports.Aliases{
+		flow.Testnet: {
+			"FlowToken":     flow.HexToAddress("7e60df042a9c0868"),
+			"FungibleToken": flow.HexToAddress("9a0766d93b6608b7"),
+		},
+		flow.Mainnet: {
+			"FlowToken":     flow.HexToAddress("1654653399040a61"),
+			"FungibleToken": flow.HexToAddress("f233dcee88fe0abe"),
+		},
+	})
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	resolver := newResolver()
+
+	t.Run("String imports", func(t *testing.T) {
+		code := []byte("import \"FlowToken\"\nimport \"FungibleToken\"\n\npub fun main() {}\n")
+
+		resolved, err := resolver.Resolve(flow.Testnet, code)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"import FlowToken from 0x7e60df042a9c0868\nimport FungibleToken from 0x9a0766d93b6608b7\n\npub fun main() {}\n",
+			string(resolved),
+		)
+
+		resolved, err = resolver.Resolve(flow.Mainnet, code)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"import FlowToken from 0x1654653399040a61\nimport FungibleToken from 0xf233dcee88fe0abe\n\npub fun main() {}\n",
+			string(resolved),
+		)
+	})
+
+	t.Run("Path imports", func(t *testing.T) {
+		code := []byte("import FungibleToken from \"../contracts/FungibleToken.cdc\"\npub fun main() {}")
+
+		resolved, err := resolver.Resolve(flow.Testnet, code)
+		require.NoError(t, err)
+		assert.Equal(t, "import FungibleToken from 0x9a0766d93b6608b7\npub fun main() {}", string(resolved))
+	})
+
+	t.Run("Placeholder imports", func(t *testing.T) {
+		code := []byte("import FungibleToken from 0xFUNGIBLETOKEN\nimport FlowToken from 0xFLOW_TOKEN\npub fun main() {}")
+
+		resolved, err := resolver.Resolve(flow.Testnet, code)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"import FungibleToken from 0x9a0766d93b6608b7\nimport FlowToken from 0x7e60df042a9c0868\npub fun main() {}",
+			string(resolved),
+		)
+	})
+
+	t.Run("Explicit placeholder alias", func(t *testing.T) {
+		r := newResolver().AddAlias(flow.Testnet, "0xFEE", flow.HexToAddress("912d5440f7e3769e"))
+
+		resolved, err := r.Resolve(flow.Testnet, []byte("import FlowFees from 0xFEE\npub fun main() {}"))
+		require.NoError(t, err)
+		assert.Equal(t, "import FlowFees from 0x912d5440f7e3769e\npub fun main() {}", string(resolved))
+	})
+
+	t.Run("Address and built-in imports are unchanged", func(t *testing.T) {
+		code := []byte("import Crypto\nimport Foo from 0x01\npub fun main() {}")
+
+		resolved, err := resolver.Resolve(flow.Testnet, code)
+		require.NoError(t, err)
+		assert.Equal(t, string(code), string(resolved))
+	})
+
+	t.Run("Unresolved imports", func(t *testing.T) {
+		code := []byte("import \"FlowToken\"\nimport Foo from \"./Foo.cdc\"\nimport Bar from 0xBAR\npub fun main() {}")
+
+		_, err := resolver.Resolve(flow.Testnet, code)
+		assert.EqualError(t, err, "unresolved imports on flow-testnet: 0xBAR")
+
+		_, err = resolver.Resolve(flow.Emulator, []byte("import \"FlowToken\"\nimport Foo from \"./Foo.cdc\"\npub fun main() {}"))
+		var unresolvedErr imports.UnresolvedImportError
+		require.ErrorAs(t, err, &unresolvedErr)
+		assert.Equal(t, []string{"FlowToken", "./Foo.cdc"}, unresolvedErr.Imports)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		_, err := resolver.Resolve(flow.Testnet, []byte("import"))
+		assert.Error(t, err)
+	})
+}
+
+func TestResolver_ResolveTransaction(t *testing.T) {
+	tx := flow.NewTransaction().SetScript([]byte("import \"FlowToken\"\ntransaction {}"))
+
+	require.NoError(t, newResolver().ResolveTransaction(flow.Mainnet, tx))
+	assert.Equal(t, "import FlowToken from 0x1654653399040a61\ntransaction {}", string(tx.Script))
+}
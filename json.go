@@ -0,0 +1,711 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// The JSON representations of the core types below follow the schema of the Flow REST API,
+// so that REST responses can be decoded directly into these types and vice versa.
+//
+// Integers are encoded as decimal strings, identifiers and addresses as hex strings,
+// and scripts, arguments, payloads and signatures as base64 strings.
+//
+// The wire types mirror the subset of the REST API models in access/http/models that is
+// needed here; they are kept private so that this package does not depend on the HTTP client.
+
+type transactionJSON struct {
+	ID                 string                     `json:"id"`
+	Script             string                     `json:"script"`
+	Arguments          []string                   `json:"arguments"`
+	ReferenceBlockID   string                     `json:"reference_block_id"`
+	GasLimit           string                     `json:"gas_limit"`
+	Payer              string                     `json:"payer"`
+	ProposalKey        *proposalKeyJSON           `json:"proposal_key"`
+	Authorizers        []string                   `json:"authorizers"`
+	PayloadSignatures  []transactionSignatureJSON `json:"payload_signatures"`
+	EnvelopeSignatures []transactionSignatureJSON `json:"envelope_signatures"`
+}
+
+type proposalKeyJSON struct {
+	Address        string `json:"address"`
+	KeyIndex       string `json:"key_index"`
+	SequenceNumber string `json:"sequence_number"`
+}
+
+type transactionSignatureJSON struct {
+	Address   string `json:"address"`
+	KeyIndex  string `json:"key_index"`
+	Signature string `json:"signature"`
+}
+
+// transactionResultJSON extends the REST API transaction result, which does not
+// identify its transaction or block height, with those fields.
+type transactionResultJSON struct {
+	BlockID       string      `json:"block_id"`
+	Execution     string      `json:"execution,omitempty"`
+	Status        string      `json:"status"`
+	StatusCode    int32       `json:"status_code"`
+	ErrorMessage  string      `json:"error_message"`
+	Events        []eventJSON `json:"events"`
+	TransactionID string      `json:"transaction_id,omitempty"`
+	BlockHeight   string      `json:"block_height,omitempty"`
+}
+
+type eventJSON struct {
+	Type             string `json:"type"`
+	TransactionID    string `json:"transaction_id"`
+	TransactionIndex string `json:"transaction_index"`
+	EventIndex       string `json:"event_index"`
+	Payload          string `json:"payload"`
+}
+
+type accountJSON struct {
+	Address   string            `json:"address"`
+	Balance   string            `json:"balance"`
+	Keys      []accountKeyJSON  `json:"keys,omitempty"`
+	Contracts map[string]string `json:"contracts,omitempty"`
+}
+
+type accountKeyJSON struct {
+	Index            string `json:"index"`
+	PublicKey        string `json:"public_key"`
+	SigningAlgorithm string `json:"signing_algorithm"`
+	HashingAlgorithm string `json:"hashing_algorithm"`
+	SequenceNumber   string `json:"sequence_number"`
+	Weight           string `json:"weight"`
+	Revoked          bool   `json:"revoked"`
+}
+
+type blockJSON struct {
+	Header      *blockHeaderJSON  `json:"header"`
+	Payload     *blockPayloadJSON `json:"payload,omitempty"`
+	BlockStatus string            `json:"block_status"`
+}
+
+type blockHeaderJSON struct {
+	ID        string    `json:"id"`
+	ParentID  string    `json:"parent_id"`
+	Height    string    `json:"height"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type blockPayloadJSON struct {
+	CollectionGuarantees []collectionGuaranteeJSON `json:"collection_guarantees"`
+	BlockSeals           []blockSealJSON           `json:"block_seals"`
+}
+
+type collectionGuaranteeJSON struct {
+	CollectionID string `json:"collection_id"`
+}
+
+type blockSealJSON struct {
+	BlockID  string `json:"block_id"`
+	ResultID string `json:"result_id"`
+}
+
+// MarshalJSON returns the REST API representation of the transaction.
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	authorizers := make([]string, len(t.Authorizers))
+	for i, address := range t.Authorizers {
+		authorizers[i] = address.Hex()
+	}
+
+	return json.Marshal(transactionJSON{
+		ID:               t.ID().String(),
+		Script:           base64.StdEncoding.EncodeToString(t.Script),
+		Arguments:        encodeBase64List(t.Arguments),
+		ReferenceBlockID: t.ReferenceBlockID.String(),
+		GasLimit:         formatUint(t.GasLimit),
+		Payer:            t.Payer.Hex(),
+		ProposalKey: &proposalKeyJSON{
+			Address:        t.ProposalKey.Address.Hex(),
+			KeyIndex:       strconv.Itoa(t.ProposalKey.KeyIndex),
+			SequenceNumber: formatUint(t.ProposalKey.SequenceNumber),
+		},
+		Authorizers:        authorizers,
+		PayloadSignatures:  encodeTransactionSignatures(t.PayloadSignatures),
+		EnvelopeSignatures: encodeTransactionSignatures(t.EnvelopeSignatures),
+	})
+}
+
+// UnmarshalJSON decodes the REST API representation of a transaction.
+//
+// The transaction ID and result included in REST responses are ignored.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	var m transactionJSON
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+
+	script, err := base64.StdEncoding.DecodeString(m.Script)
+	if err != nil {
+		return fmt.Errorf("failed to decode transaction script: %w", err)
+	}
+
+	arguments, err := decodeBase64List(m.Arguments)
+	if err != nil {
+		return fmt.Errorf("failed to decode transaction arguments: %w", err)
+	}
+
+	gasLimit, err := parseUint("gas limit", m.GasLimit)
+	if err != nil {
+		return err
+	}
+
+	var proposalKey ProposalKey
+	if m.ProposalKey != nil {
+		keyIndex, err := parseInt("proposal key index", m.ProposalKey.KeyIndex)
+		if err != nil {
+			return err
+		}
+
+		sequenceNumber, err := parseUint("proposal key sequence number", m.ProposalKey.SequenceNumber)
+		if err != nil {
+			return err
+		}
+
+		proposalKey = ProposalKey{
+			Address:        HexToAddress(m.ProposalKey.Address),
+			KeyIndex:       keyIndex,
+			SequenceNumber: sequenceNumber,
+		}
+	}
+
+	var authorizers []Address
+	for _, address := range m.Authorizers {
+		authorizers = append(authorizers, HexToAddress(address))
+	}
+
+	payloadSignatures, err := decodeTransactionSignatures(m.PayloadSignatures)
+	if err != nil {
+		return fmt.Errorf("failed to decode payload signatures: %w", err)
+	}
+
+	envelopeSignatures, err := decodeTransactionSignatures(m.EnvelopeSignatures)
+	if err != nil {
+		return fmt.Errorf("failed to decode envelope signatures: %w", err)
+	}
+
+	*t = Transaction{
+		Script:             script,
+		Arguments:          arguments,
+		ReferenceBlockID:   HexToID(m.ReferenceBlockID),
+		GasLimit:           gasLimit,
+		ProposalKey:        proposalKey,
+		Payer:              HexToAddress(m.Payer),
+		Authorizers:        authorizers,
+		PayloadSignatures:  payloadSignatures,
+		EnvelopeSignatures: envelopeSignatures,
+	}
+	t.refreshSignerIndex()
+
+	return nil
+}
+
+func encodeTransactionSignatures(signatures []TransactionSignature) []transactionSignatureJSON {
+	encoded := make([]transactionSignatureJSON, len(signatures))
+	for i, sig := range signatures {
+		encoded[i] = transactionSignatureJSON{
+			Address:   sig.Address.Hex(),
+			KeyIndex:  strconv.Itoa(sig.KeyIndex),
+			Signature: base64.StdEncoding.EncodeToString(sig.Signature),
+		}
+	}
+
+	return encoded
+}
+
+func decodeTransactionSignatures(signatures []transactionSignatureJSON) ([]TransactionSignature, error) {
+	var decoded []TransactionSignature
+	for _, sig := range signatures {
+		keyIndex, err := parseInt("signature key index", sig.KeyIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(sig.Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		decoded = append(decoded, TransactionSignature{
+			Address:   HexToAddress(sig.Address),
+			KeyIndex:  keyIndex,
+			Signature: signature,
+		})
+	}
+
+	return decoded, nil
+}
+
+var transactionStatusNames = map[TransactionStatus]string{
+	TransactionStatusPending:   "Pending",
+	TransactionStatusFinalized: "Finalized",
+	TransactionStatusExecuted:  "Executed",
+	TransactionStatusSealed:    "Sealed",
+	TransactionStatusExpired:   "Expired",
+}
+
+// MarshalJSON returns the REST API representation of the transaction result.
+//
+// In addition to the REST API fields, the transaction ID and block height are included.
+func (r TransactionResult) MarshalJSON() ([]byte, error) {
+	status, ok := transactionStatusNames[r.Status]
+	if !ok {
+		status = "Unknown"
+	}
+
+	execution := "Pending"
+	var statusCode int32
+	var errorMessage string
+	if r.Error != nil {
+		execution = "Failure"
+		statusCode = 1
+		errorMessage = r.Error.Error()
+	} else if r.Status == TransactionStatusExecuted || r.Status == TransactionStatusSealed {
+		execution = "Success"
+	}
+
+	events := make([]eventJSON, len(r.Events))
+	for i, event := range r.Events {
+		events[i] = event.toJSON()
+	}
+
+	m := transactionResultJSON{
+		BlockID:      r.BlockID.String(),
+		Execution:    execution,
+		Status:       status,
+		StatusCode:   statusCode,
+		ErrorMessage: errorMessage,
+		Events:       events,
+	}
+
+	if r.TransactionID != EmptyID {
+		m.TransactionID = r.TransactionID.String()
+	}
+	if r.BlockHeight != 0 {
+		m.BlockHeight = formatUint(r.BlockHeight)
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes the REST API representation of a transaction result.
+func (r *TransactionResult) UnmarshalJSON(data []byte) error {
+	var m transactionResultJSON
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+
+	status := TransactionStatusUnknown
+	for s, name := range transactionStatusNames {
+		if name == m.Status {
+			status = s
+			break
+		}
+	}
+
+	var txErr error
+	if m.ErrorMessage != "" {
+		txErr = ParseTransactionExecutionError(m.ErrorMessage)
+	}
+
+	var events []Event
+	for _, e := range m.Events {
+		event, err := eventFromJSON(e)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+
+	var blockHeight uint64
+	if m.BlockHeight != "" {
+		blockHeight, err = parseUint("block height", m.BlockHeight)
+		if err != nil {
+			return err
+		}
+	}
+
+	var transactionID Identifier
+	if m.TransactionID != "" {
+		transactionID = HexToID(m.TransactionID)
+	}
+
+	*r = TransactionResult{
+		Status:        status,
+		Error:         txErr,
+		Events:        events,
+		BlockID:       HexToID(m.BlockID),
+		BlockHeight:   blockHeight,
+		TransactionID: transactionID,
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the REST API representation of the event.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// UnmarshalJSON decodes the REST API representation of an event, including its JSON-CDC payload.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var m eventJSON
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+
+	event, err := eventFromJSON(m)
+	if err != nil {
+		return err
+	}
+
+	*e = event
+	return nil
+}
+
+func (e Event) toJSON() eventJSON {
+	return eventJSON{
+		Type:             e.Type,
+		TransactionID:    e.TransactionID.String(),
+		TransactionIndex: strconv.Itoa(e.TransactionIndex),
+		EventIndex:       strconv.Itoa(e.EventIndex),
+		Payload:          base64.StdEncoding.EncodeToString(e.Payload),
+	}
+}
+
+func eventFromJSON(m eventJSON) (Event, error) {
+	transactionIndex, err := parseInt("event transaction index", m.TransactionIndex)
+	if err != nil {
+		return Event{}, err
+	}
+
+	eventIndex, err := parseInt("event index", m.EventIndex)
+	if err != nil {
+		return Event{}, err
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(m.Payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to decode event payload: %w", err)
+	}
+
+	event := Event{
+		Type:             m.Type,
+		TransactionID:    HexToID(m.TransactionID),
+		TransactionIndex: transactionIndex,
+		EventIndex:       eventIndex,
+		Payload:          payload,
+	}
+
+	if len(payload) > 0 {
+		value, err := jsoncdc.Decode(nil, payload)
+		if err != nil {
+			return Event{}, fmt.Errorf("failed to decode event payload: %w", err)
+		}
+
+		cadenceEvent, ok := value.(cadence.Event)
+		if !ok {
+			return Event{}, fmt.Errorf("event payload is not an event: %s", value.Type().ID())
+		}
+		event.Value = cadenceEvent
+	}
+
+	return event, nil
+}
+
+// MarshalJSON returns the REST API representation of the account.
+func (a Account) MarshalJSON() ([]byte, error) {
+	keys := make([]accountKeyJSON, len(a.Keys))
+	for i, key := range a.Keys {
+		keys[i] = key.toJSON()
+	}
+
+	contracts := make(map[string]string, len(a.Contracts))
+	for name, code := range a.Contracts {
+		contracts[name] = base64.StdEncoding.EncodeToString(code)
+	}
+
+	return json.Marshal(accountJSON{
+		Address:   a.Address.Hex(),
+		Balance:   formatUint(a.Balance),
+		Keys:      keys,
+		Contracts: contracts,
+	})
+}
+
+// UnmarshalJSON decodes the REST API representation of an account.
+func (a *Account) UnmarshalJSON(data []byte) error {
+	var m accountJSON
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+
+	balance, err := parseUint("account balance", m.Balance)
+	if err != nil {
+		return err
+	}
+
+	var keys []*AccountKey
+	for _, k := range m.Keys {
+		key, err := accountKeyFromJSON(k)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	var contracts map[string][]byte
+	if len(m.Contracts) > 0 {
+		contracts = make(map[string][]byte, len(m.Contracts))
+		for name, code := range m.Contracts {
+			contracts[name], err = base64.StdEncoding.DecodeString(code)
+			if err != nil {
+				return fmt.Errorf("failed to decode contract %s: %w", name, err)
+			}
+		}
+	}
+
+	*a = Account{
+		Address:   HexToAddress(m.Address),
+		Balance:   balance,
+		Keys:      keys,
+		Contracts: contracts,
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the REST API representation of the account key.
+func (a AccountKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.toJSON())
+}
+
+// UnmarshalJSON decodes the REST API representation of an account key.
+func (a *AccountKey) UnmarshalJSON(data []byte) error {
+	var m accountKeyJSON
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+
+	key, err := accountKeyFromJSON(m)
+	if err != nil {
+		return err
+	}
+
+	*a = *key
+	return nil
+}
+
+func (a AccountKey) toJSON() accountKeyJSON {
+	var publicKey string
+	if a.PublicKey != nil {
+		publicKey = a.PublicKey.String()
+	}
+
+	return accountKeyJSON{
+		Index:            strconv.Itoa(a.Index),
+		PublicKey:        publicKey,
+		SigningAlgorithm: a.SigAlgo.String(),
+		HashingAlgorithm: a.HashAlgo.String(),
+		SequenceNumber:   formatUint(a.SequenceNumber),
+		Weight:           strconv.Itoa(a.Weight),
+		Revoked:          a.Revoked,
+	}
+}
+
+func accountKeyFromJSON(m accountKeyJSON) (*AccountKey, error) {
+	index, err := parseInt("account key index", m.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := parseInt("account key weight", m.Weight)
+	if err != nil {
+		return nil, err
+	}
+
+	sequenceNumber, err := parseUint("account key sequence number", m.SequenceNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &AccountKey{
+		Index:          index,
+		SigAlgo:        crypto.UnknownSignatureAlgorithm,
+		HashAlgo:       crypto.UnknownHashAlgorithm,
+		Weight:         weight,
+		SequenceNumber: sequenceNumber,
+		Revoked:        m.Revoked,
+	}
+
+	if m.SigningAlgorithm != "" {
+		key.SigAlgo = crypto.StringToSignatureAlgorithm(m.SigningAlgorithm)
+	}
+	if m.HashingAlgorithm != "" {
+		key.HashAlgo = crypto.StringToHashAlgorithm(m.HashingAlgorithm)
+	}
+
+	if m.PublicKey != "" {
+		key.PublicKey, err = crypto.DecodePublicKeyHex(key.SigAlgo, strings.TrimPrefix(m.PublicKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key of account key %d: %w", index, err)
+		}
+	}
+
+	return key, nil
+}
+
+// MarshalJSON returns the REST API representation of the block.
+func (b Block) MarshalJSON() ([]byte, error) {
+	guarantees := make([]collectionGuaranteeJSON, len(b.CollectionGuarantees))
+	for i, guarantee := range b.CollectionGuarantees {
+		guarantees[i] = collectionGuaranteeJSON{
+			CollectionID: guarantee.CollectionID.String(),
+		}
+	}
+
+	seals := make([]blockSealJSON, len(b.Seals))
+	for i, seal := range b.Seals {
+		seals[i] = blockSealJSON{
+			BlockID:  seal.BlockID.String(),
+			ResultID: seal.ExecutionReceiptID.String(),
+		}
+	}
+
+	return json.Marshal(blockJSON{
+		Header: &blockHeaderJSON{
+			ID:        b.ID.String(),
+			ParentID:  b.ParentID.String(),
+			Height:    formatUint(b.Height),
+			Timestamp: b.Timestamp,
+		},
+		Payload: &blockPayloadJSON{
+			CollectionGuarantees: guarantees,
+			BlockSeals:           seals,
+		},
+		BlockStatus: b.Status.String(),
+	})
+}
+
+// UnmarshalJSON decodes the REST API representation of a block.
+func (b *Block) UnmarshalJSON(data []byte) error {
+	var m blockJSON
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+
+	block := Block{
+		BlockHeader: BlockHeader{
+			Status: BlockStatusFromString(m.BlockStatus),
+		},
+	}
+
+	if m.Header != nil {
+		block.Height, err = parseUint("block height", m.Header.Height)
+		if err != nil {
+			return err
+		}
+
+		block.ID = HexToID(m.Header.ID)
+		block.ParentID = HexToID(m.Header.ParentID)
+		block.Timestamp = m.Header.Timestamp
+	}
+
+	if m.Payload != nil {
+		for _, guarantee := range m.Payload.CollectionGuarantees {
+			block.CollectionGuarantees = append(block.CollectionGuarantees, &CollectionGuarantee{
+				CollectionID: HexToID(guarantee.CollectionID),
+			})
+		}
+
+		for _, seal := range m.Payload.BlockSeals {
+			block.Seals = append(block.Seals, &BlockSeal{
+				BlockID:            HexToID(seal.BlockID),
+				ExecutionReceiptID: HexToID(seal.ResultID),
+			})
+		}
+	}
+
+	*b = block
+	return nil
+}
+
+func formatUint(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
+
+func parseUint(name string, value string) (uint64, error) {
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+
+	return parsed, nil
+}
+
+func parseInt(name string, value string) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+
+	return parsed, nil
+}
+
+func encodeBase64List(values [][]byte) []string {
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		encoded[i] = base64.StdEncoding.EncodeToString(value)
+	}
+
+	return encoded
+}
+
+func decodeBase64List(values []string) ([][]byte, error) {
+	var decoded [][]byte
+	for _, value := range values {
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, b)
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,155 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestTransaction_JSON(t *testing.T) {
+	tx := test.TransactionGenerator().New()
+
+	b, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(b, &fields))
+	assert.Equal(t, tx.ID().String(), fields["id"])
+	assert.Equal(t, tx.Payer.Hex(), fields["payer"])
+
+	var decoded flow.Transaction
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, tx.ID(), decoded.ID())
+	assert.Equal(t, tx.PayloadSignatures, decoded.PayloadSignatures)
+	assert.Equal(t, tx.EnvelopeSignatures, decoded.EnvelopeSignatures)
+}
+
+func TestTransaction_UnmarshalRESTJSON(t *testing.T) {
+	data := `{
+		"id": "ignored",
+		"script": "dHJhbnNhY3Rpb24ge30=",
+		"arguments": ["eyJ0eXBlIjoiU3RyaW5nIiwidmFsdWUiOiJmb28ifQ=="],
+		"reference_block_id": "7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7",
+		"gas_limit": "9999",
+		"payer": "f8d6e0586b0a20c7",
+		"proposal_key": {"address": "f8d6e0586b0a20c7", "key_index": "1", "sequence_number": "42"},
+		"authorizers": ["f8d6e0586b0a20c7"],
+		"payload_signatures": [],
+		"envelope_signatures": [{"address": "f8d6e0586b0a20c7", "key_index": "1", "signature": "AQI="}],
+		"_expandable": {"result": "/v1/transaction_results/1"}
+	}`
+
+	var tx flow.Transaction
+	require.NoError(t, json.Unmarshal([]byte(data), &tx))
+
+	address := flow.HexToAddress("f8d6e0586b0a20c7")
+	assert.Equal(t, []byte("transaction {}"), tx.Script)
+	assert.Equal(t, [][]byte{[]byte(`{"type":"String","value":"foo"}`)}, tx.Arguments)
+	assert.Equal(t, uint64(9999), tx.GasLimit)
+	assert.Equal(t, flow.ProposalKey{Address: address, KeyIndex: 1, SequenceNumber: 42}, tx.ProposalKey)
+	assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+	assert.Equal(t, []flow.TransactionSignature{{Address: address, KeyIndex: 1, Signature: []byte{1, 2}}}, tx.EnvelopeSignatures)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"gas_limit": "lots"}`), &tx))
+}
+
+func TestTransactionResult_JSON(t *testing.T) {
+	result := test.TransactionResultGenerator().New()
+	result.TransactionID = test.IdentifierGenerator().New()
+
+	b, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(b, &fields))
+	assert.Equal(t, "Sealed", fields["status"])
+	assert.Equal(t, "Failure", fields["execution"])
+	assert.Equal(t, "transaction execution error", fields["error_message"])
+
+	var decoded flow.TransactionResult
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, result.Status, decoded.Status)
+	assert.EqualError(t, decoded.Error, result.Error.Error())
+	assert.Equal(t, result.BlockID, decoded.BlockID)
+	assert.Equal(t, result.BlockHeight, decoded.BlockHeight)
+	assert.Equal(t, result.TransactionID, decoded.TransactionID)
+	require.Len(t, decoded.Events, len(result.Events))
+	assert.Equal(t, result.Events[0].Payload, decoded.Events[0].Payload)
+}
+
+func TestEvent_JSON(t *testing.T) {
+	event := test.EventGenerator().New()
+
+	b, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var decoded flow.Event
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, event.Type, decoded.Type)
+	assert.Equal(t, event.TransactionID, decoded.TransactionID)
+	assert.Equal(t, event.TransactionIndex, decoded.TransactionIndex)
+	assert.Equal(t, event.EventIndex, decoded.EventIndex)
+	assert.Equal(t, event.Payload, decoded.Payload)
+	assert.Equal(t, event.Value.String(), decoded.Value.String())
+}
+
+func TestAccount_JSON(t *testing.T) {
+	account := test.AccountGenerator().New()
+	account.Code = nil
+	account.Contracts = map[string][]byte{"Foo": []byte("pub contract Foo {}")}
+
+	b, err := json.Marshal(account)
+	require.NoError(t, err)
+
+	var decoded flow.Account
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, *account, decoded)
+
+	key := account.Keys[0]
+	b, err = json.Marshal(key)
+	require.NoError(t, err)
+
+	var decodedKey flow.AccountKey
+	require.NoError(t, json.Unmarshal(b, &decodedKey))
+	assert.Equal(t, *key, decodedKey)
+}
+
+func TestBlock_JSON(t *testing.T) {
+	block := test.BlockGenerator().New()
+	block.Status = flow.BlockStatusSealed
+
+	b, err := json.Marshal(block)
+	require.NoError(t, err)
+
+	var decoded flow.Block
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, block.ID, decoded.ID)
+	assert.Equal(t, block.ParentID, decoded.ParentID)
+	assert.Equal(t, block.Height, decoded.Height)
+	assert.True(t, block.Timestamp.Equal(decoded.Timestamp))
+	assert.Equal(t, block.Status, decoded.Status)
+	assert.Equal(t, block.BlockPayload, decoded.BlockPayload)
+}
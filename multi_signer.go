@@ -0,0 +1,254 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// A MultiSigner bundles the signers of several keys of one account, so that a transaction
+// can be signed with all of them at once.
+//
+// This is useful for accounts whose keys each carry only part of AccountKeyWeightThreshold,
+// such as two KMS keys with a weight of 500 each.
+type MultiSigner struct {
+	Address Address
+	Keys    []MultiSignerKey
+}
+
+// A MultiSignerKey is an account key index paired with the signer for that key.
+//
+// Several keys may share the same signer, for example keys that were added with the same
+// public key. Keys with equal signers sign one after another, as signers such as
+// crypto.InMemorySigner are not safe for concurrent use; distinct signers must not share state.
+type MultiSignerKey struct {
+	KeyIndex int
+	Signer   crypto.Signer
+}
+
+// NewMultiSigner creates a multi-signer for the given keys of an account.
+func NewMultiSigner(address Address, keys ...MultiSignerKey) *MultiSigner {
+	return &MultiSigner{
+		Address: address,
+		Keys:    keys,
+	}
+}
+
+// MultiSigner returns a multi-signer for the keys selected by this plan.
+func (p *SigningPlan) MultiSigner() *MultiSigner {
+	keys := make([]MultiSignerKey, len(p.Keys))
+	for i, key := range p.Keys {
+		keys[i] = MultiSignerKey{KeyIndex: key.Key.Index, Signer: key.Signer}
+	}
+
+	return NewMultiSigner(p.Address, keys...)
+}
+
+// A MultiSignError is returned when some keys of a MultiSigner fail to sign.
+//
+// No signatures are added to the transaction in that case.
+type MultiSignError struct {
+	Address Address
+	// Signed are the indices of the keys that produced a signature.
+	Signed []int
+	// Failed maps the indices of the keys that failed to sign to their errors.
+	Failed map[int]error
+}
+
+func (e *MultiSignError) Error() string {
+	failed := e.failedKeys()
+
+	messages := make([]string, len(failed))
+	for i, keyIndex := range failed {
+		messages[i] = fmt.Sprintf("key %d: %s", keyIndex, e.Failed[keyIndex])
+	}
+
+	return fmt.Sprintf(
+		"failed to sign with %d of %d keys of %s (signed with keys %v): %s",
+		len(failed),
+		len(failed)+len(e.Signed),
+		e.Address,
+		e.Signed,
+		strings.Join(messages, "; "),
+	)
+}
+
+// Unwrap returns the error of the failed key with the lowest index.
+func (e *MultiSignError) Unwrap() error {
+	failed := e.failedKeys()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return e.Failed[failed[0]]
+}
+
+func (e *MultiSignError) failedKeys() []int {
+	keys := make([]int, 0, len(e.Failed))
+	for keyIndex := range e.Failed {
+		keys = append(keys, keyIndex)
+	}
+	sort.Ints(keys)
+
+	return keys
+}
+
+// SignPayloadMulti signs the transaction payload with every key of the multi-signer.
+//
+// Keys with distinct signers sign concurrently, and their signatures are added at once. If any key fails to sign,
+// a *MultiSignError is returned and no signatures are added.
+func (t *Transaction) SignPayloadMulti(signer *MultiSigner) error {
+	return t.SignPayloadMultiWithContext(context.Background(), signer)
+}
+
+// SignEnvelopeMulti signs the full transaction with every key of the multi-signer.
+//
+// Keys with distinct signers sign concurrently, and their signatures are added at once. If any key fails to sign,
+// a *MultiSignError is returned and no signatures are added.
+func (t *Transaction) SignEnvelopeMulti(signer *MultiSigner) error {
+	return t.SignEnvelopeMultiWithContext(context.Background(), signer)
+}
+
+// SignPayloadMultiWithContext signs the transaction payload like SignPayloadMulti, passing the
+// context to signers that are a crypto.ContextSigner.
+func (t *Transaction) SignPayloadMultiWithContext(ctx context.Context, signer *MultiSigner) error {
+	sigs, err := t.signMulti(ctx, signer, t.PayloadMessage())
+	if err != nil {
+		return err
+	}
+
+	t.PayloadSignatures = append(t.PayloadSignatures, sigs...)
+	sort.Slice(t.PayloadSignatures, compareSignatures(t.PayloadSignatures))
+	t.refreshSignerIndex()
+
+	return nil
+}
+
+// SignEnvelopeMultiWithContext signs the full transaction like SignEnvelopeMulti, passing the
+// context to signers that are a crypto.ContextSigner.
+func (t *Transaction) SignEnvelopeMultiWithContext(ctx context.Context, signer *MultiSigner) error {
+	sigs, err := t.signMulti(ctx, signer, t.EnvelopeMessage())
+	if err != nil {
+		return err
+	}
+
+	t.EnvelopeSignatures = append(t.EnvelopeSignatures, sigs...)
+	sort.Slice(t.EnvelopeSignatures, compareSignatures(t.EnvelopeSignatures))
+	t.refreshSignerIndex()
+
+	return nil
+}
+
+// signMulti signs the domain-tagged message with every key of the multi-signer, running the
+// distinct signers concurrently.
+func (t *Transaction) signMulti(ctx context.Context, signer *MultiSigner, message []byte) ([]TransactionSignature, error) {
+	if len(signer.Keys) == 0 {
+		return nil, fmt.Errorf("multi-signer for %s has no keys", signer.Address)
+	}
+
+	seen := make(map[int]bool, len(signer.Keys))
+	for _, key := range signer.Keys {
+		if seen[key.KeyIndex] {
+			return nil, fmt.Errorf("multi-signer for %s has key %d more than once", signer.Address, key.KeyIndex)
+		}
+		seen[key.KeyIndex] = true
+	}
+
+	message = append(TransactionDomainTag[:], message...)
+
+	sigs := make([][]byte, len(signer.Keys))
+	errs := make([]error, len(signer.Keys))
+
+	var wg sync.WaitGroup
+	for _, group := range groupKeysBySigner(signer.Keys) {
+		wg.Add(1)
+		go func(group []int) {
+			defer wg.Done()
+			for _, i := range group {
+				sigs[i], errs[i] = crypto.SignWithContext(ctx, signer.Keys[i].Signer, message)
+			}
+		}(group)
+	}
+	wg.Wait()
+
+	var signErr *MultiSignError
+	for i, key := range signer.Keys {
+		if errs[i] == nil {
+			continue
+		}
+
+		if signErr == nil {
+			signErr = &MultiSignError{
+				Address: signer.Address,
+				Failed:  make(map[int]error),
+			}
+		}
+		signErr.Failed[key.KeyIndex] = errs[i]
+	}
+
+	if signErr != nil {
+		for i, key := range signer.Keys {
+			if errs[i] == nil {
+				signErr.Signed = append(signErr.Signed, key.KeyIndex)
+			}
+		}
+		sort.Ints(signErr.Signed)
+
+		return nil, signErr
+	}
+
+	signatures := make([]TransactionSignature, len(signer.Keys))
+	for i, key := range signer.Keys {
+		signatures[i] = t.createSignature(signer.Address, key.KeyIndex, sigs[i])
+	}
+
+	return signatures, nil
+}
+
+// groupKeysBySigner returns the indices of the keys grouped by equal signers.
+//
+// Signers of types that cannot be compared are never grouped.
+func groupKeysBySigner(keys []MultiSignerKey) [][]int {
+	var groups [][]int
+	groupIndex := make(map[crypto.Signer]int)
+
+	for i, key := range keys {
+		if key.Signer == nil || !reflect.TypeOf(key.Signer).Comparable() {
+			groups = append(groups, []int{i})
+			continue
+		}
+
+		if g, ok := groupIndex[key.Signer]; ok {
+			groups[g] = append(groups[g], i)
+			continue
+		}
+
+		groupIndex[key.Signer] = len(groups)
+		groups = append(groups, []int{i})
+	}
+
+	return groups
+}
@@ -0,0 +1,196 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type failingSigner struct {
+	crypto.Signer
+	err error
+}
+
+func (s failingSigner) Sign([]byte) ([]byte, error) {
+	return nil, s.err
+}
+
+// barrierSigner only signs once all signers of the barrier have been called.
+type barrierSigner struct {
+	crypto.Signer
+	barrier *sync.WaitGroup
+}
+
+func (s barrierSigner) Sign(message []byte) ([]byte, error) {
+	s.barrier.Done()
+
+	done := make(chan struct{})
+	go func() {
+		s.barrier.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return s.Signer.Sign(message)
+	case <-time.After(5 * time.Second):
+		return nil, errors.New("signers were not called concurrently")
+	}
+}
+
+func newMultiSigner(a *signingAccount) *flow.MultiSigner {
+	keys := make([]flow.MultiSignerKey, len(a.signers))
+	for i, signer := range a.signers {
+		keys[i] = flow.MultiSignerKey{KeyIndex: a.account.Keys[i].Index, Signer: signer}
+	}
+
+	return flow.NewMultiSigner(a.account.Address, keys...)
+}
+
+func TestTransaction_SignMulti(t *testing.T) {
+	newTransaction := func() (*flow.Transaction, *signingAccount, *signingAccount) {
+		accounts := newSigningAccounts([]int{500, 500}, []int{500, 500})
+		authorizer, payer := accounts[0], accounts[1]
+
+		tx := flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(authorizer.account.Address, 0, 0).
+			SetPayer(payer.account.Address).
+			AddAuthorizer(authorizer.account.Address)
+
+		return tx, authorizer, payer
+	}
+
+	t.Run("Payload and envelope", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+
+		require.NoError(t, tx.SignPayloadMulti(newMultiSigner(authorizer)))
+		require.NoError(t, tx.SignEnvelopeMulti(newMultiSigner(payer)))
+
+		require.Len(t, tx.PayloadSignatures, 2)
+		require.Len(t, tx.EnvelopeSignatures, 2)
+		assert.Equal(t, 0, tx.PayloadSignatures[0].SignerIndex)
+		assert.Equal(t, 1, tx.EnvelopeSignatures[1].SignerIndex)
+
+		assert.NoError(t, tx.VerifySignatures(lookupAccounts(authorizer, payer)))
+	})
+
+	t.Run("Signs concurrently", func(t *testing.T) {
+		tx, authorizer, _ := newTransaction()
+
+		var barrier sync.WaitGroup
+		barrier.Add(len(authorizer.signers))
+
+		signer := newMultiSigner(authorizer)
+		for i := range signer.Keys {
+			signer.Keys[i].Signer = barrierSigner{Signer: signer.Keys[i].Signer, barrier: &barrier}
+		}
+
+		require.NoError(t, tx.SignPayloadMulti(signer))
+		assert.Len(t, tx.PayloadSignatures, 2)
+	})
+
+	t.Run("Shared signer", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+
+		// both keys were added with the same public key and are signed by one in-memory signer
+		keys := authorizer.account.Keys
+		keys[1].PublicKey, keys[1].SigAlgo, keys[1].HashAlgo = keys[0].PublicKey, keys[0].SigAlgo, keys[0].HashAlgo
+
+		inMemorySigner := authorizer.signers[0].(crypto.InMemorySigner)
+		hasher := &test.ExclusiveHasher{Hasher: inMemorySigner.Hasher}
+		inMemorySigner.Hasher = hasher
+
+		signer := flow.NewMultiSigner(
+			authorizer.account.Address,
+			flow.MultiSignerKey{KeyIndex: 0, Signer: inMemorySigner},
+			flow.MultiSignerKey{KeyIndex: 1, Signer: inMemorySigner},
+		)
+
+		require.NoError(t, tx.SignPayloadMulti(signer))
+		require.NoError(t, tx.SignEnvelopeMulti(newMultiSigner(payer)))
+
+		assert.False(t, hasher.UsedConcurrently())
+		assert.NoError(t, tx.VerifySignatures(lookupAccounts(authorizer, payer)))
+	})
+
+	t.Run("From signing plan", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+
+		plan, err := flow.PlanSigningKeys(authorizer.account, flow.NewKeySigners(authorizer.signers...))
+		require.NoError(t, err)
+		require.NoError(t, tx.SignPayloadMulti(plan.MultiSigner()))
+
+		require.NoError(t, tx.SignEnvelopeMulti(newMultiSigner(payer)))
+		assert.NoError(t, tx.VerifySignatures(lookupAccounts(authorizer, payer)))
+	})
+
+	t.Run("Backend failure", func(t *testing.T) {
+		tx, authorizer, _ := newTransaction()
+		backendErr := errors.New("kms unavailable")
+
+		signer := newMultiSigner(authorizer)
+		signer.Keys[1].Signer = failingSigner{Signer: signer.Keys[1].Signer, err: backendErr}
+
+		err := tx.SignPayloadMulti(signer)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, backendErr)
+
+		var signErr *flow.MultiSignError
+		require.True(t, errors.As(err, &signErr))
+		assert.Equal(t, authorizer.account.Address, signErr.Address)
+		assert.Equal(t, []int{0}, signErr.Signed)
+		assert.Equal(t, map[int]error{1: backendErr}, signErr.Failed)
+
+		assert.Empty(t, tx.PayloadSignatures)
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		tx, authorizer, _ := newTransaction()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := tx.SignPayloadMultiWithContext(ctx, newMultiSigner(authorizer))
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, tx.PayloadSignatures)
+	})
+
+	t.Run("Invalid multi-signer", func(t *testing.T) {
+		tx, authorizer, _ := newTransaction()
+
+		assert.Error(t, tx.SignPayloadMulti(flow.NewMultiSigner(authorizer.account.Address)))
+
+		signer := newMultiSigner(authorizer)
+		signer.Keys[1].KeyIndex = signer.Keys[0].KeyIndex
+		assert.Error(t, tx.SignPayloadMulti(signer))
+	})
+}
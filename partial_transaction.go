@@ -0,0 +1,412 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+var (
+	// ErrPayloadMismatch is returned when the payload of a partially signed transaction
+	// does not match the payload it was created with.
+	ErrPayloadMismatch = errors.New("transaction payload mismatch")
+	// ErrSignerNotRequired is returned when a key that is not a required signer signs a partially signed transaction.
+	ErrSignerNotRequired = errors.New("signer not required")
+	// ErrPayloadSignaturesIncomplete is returned when the envelope is signed before all payload signatures are present.
+	ErrPayloadSignaturesIncomplete = errors.New("payload signatures incomplete")
+	// ErrMetadataMismatch is returned when the argument previews or required signatures of a
+	// partially signed transaction do not match its transaction.
+	ErrMetadataMismatch = errors.New("partially signed transaction metadata mismatch")
+	// ErrPayloadNotVerified is returned when signing or merging into a partially signed transaction
+	// whose payload hash has not been checked against an expected hash.
+	ErrPayloadNotVerified = errors.New("transaction payload not verified")
+	// ErrAlreadySigned is returned when a key signs a partially signed transaction it has already signed.
+	ErrAlreadySigned = errors.New("key already signed")
+	// ErrEnvelopeSigned is returned when payload signatures are added to a partially signed
+	// transaction whose envelope is already signed.
+	ErrEnvelopeSigned = errors.New("envelope already signed")
+)
+
+// SignatureRole indicates which part of a transaction a key signs.
+type SignatureRole string
+
+const (
+	// SignatureRolePayload is the role of proposer and authorizer keys that sign the transaction payload.
+	SignatureRolePayload SignatureRole = "payload"
+	// SignatureRoleEnvelope is the role of payer keys that sign the transaction envelope.
+	SignatureRoleEnvelope SignatureRole = "envelope"
+)
+
+// A RequiredSignature is an account key that must sign a partially signed transaction.
+type RequiredSignature struct {
+	Address  Address       `json:"address"`
+	KeyIndex int           `json:"keyIndex"`
+	Role     SignatureRole `json:"role"`
+	Signed   bool          `json:"signed"`
+}
+
+// A PartiallySignedTransaction is a serializable transaction that is passed between the
+// parties of a multi-party signing process.
+//
+// It carries the encoded transaction together with the list of keys that must sign it,
+// which of them have already signed and human-readable previews of the arguments.
+// The payload hash is fixed when the partially signed transaction is created, so that
+// any modification of the payload between signing rounds is detected. The previews and
+// required signatures are checked against the transaction whenever it is decoded.
+//
+// As the payload hash travels with the transaction, a party receiving a partially signed
+// transaction must pin the hash it expects, either with DecodePartiallySignedTransaction
+// or Verify, before it can sign or merge into it.
+type PartiallySignedTransaction struct {
+	// Transaction is the encoded transaction, as returned by Transaction.Encode.
+	Transaction []byte `json:"transaction"`
+	// PayloadHash is the hex-encoded SHA3-256 hash of the transaction payload message.
+	PayloadHash string `json:"payloadHash"`
+	// Signatures lists the keys that must sign the transaction.
+	Signatures []RequiredSignature `json:"signatures"`
+	// Arguments are human-readable previews of the transaction arguments.
+	Arguments []string `json:"arguments"`
+
+	// expectedPayloadHash is the payload hash pinned by the creator or the receiving party.
+	expectedPayloadHash string
+}
+
+// A SigningKey identifies an account key.
+type SigningKey struct {
+	Address  Address
+	KeyIndex int
+}
+
+// NewPartiallySignedTransaction creates a partially signed transaction that requires
+// signatures from the given keys.
+//
+// Keys of the payer account sign the envelope, all other keys sign the payload.
+// Signatures already present on the transaction are retained.
+func NewPartiallySignedTransaction(tx *Transaction, keys ...SigningKey) (*PartiallySignedTransaction, error) {
+	signers := tx.signerMap()
+
+	required := make([]RequiredSignature, len(keys))
+	for i, key := range keys {
+		if _, ok := signers[key.Address]; !ok {
+			return nil, fmt.Errorf("%w: %s is not a proposer, payer or authorizer", ErrSignerNotRequired, key.Address)
+		}
+
+		required[i] = RequiredSignature{
+			Address:  key.Address,
+			KeyIndex: key.KeyIndex,
+			Role:     signatureRole(tx, key.Address),
+		}
+	}
+
+	hash := payloadHash(tx)
+
+	p := &PartiallySignedTransaction{
+		PayloadHash:         hash,
+		Signatures:          required,
+		Arguments:           argumentPreviews(tx),
+		expectedPayloadHash: hash,
+	}
+	p.update(tx)
+
+	return p, nil
+}
+
+// signatureRole returns the role in which keys of the given account sign the transaction.
+func signatureRole(tx *Transaction, address Address) SignatureRole {
+	if address == tx.Payer {
+		return SignatureRoleEnvelope
+	}
+
+	return SignatureRolePayload
+}
+
+func argumentPreviews(tx *Transaction) []string {
+	previews := make([]string, len(tx.Arguments))
+	for i, arg := range tx.Arguments {
+		value, err := jsoncdc.Decode(nil, arg, jsoncdc.WithAllowUnstructuredStaticTypes(true))
+		if err != nil {
+			previews[i] = string(bytes.TrimSpace(arg))
+			continue
+		}
+		previews[i] = value.String()
+	}
+
+	return previews
+}
+
+func payloadHash(tx *Transaction) string {
+	return hex.EncodeToString(defaultEntityHasher.ComputeHash(tx.PayloadMessage()))
+}
+
+// update stores the encoded transaction and refreshes which required signatures are present.
+func (p *PartiallySignedTransaction) update(tx *Transaction) {
+	p.Transaction = tx.Encode()
+
+	for i, required := range p.Signatures {
+		signatures := tx.PayloadSignatures
+		if required.Role == SignatureRoleEnvelope {
+			signatures = tx.EnvelopeSignatures
+		}
+
+		p.Signatures[i].Signed = hasSignature(signatures, required.Address, required.KeyIndex)
+	}
+}
+
+func hasSignature(signatures []TransactionSignature, address Address, keyIndex int) bool {
+	for _, sig := range signatures {
+		if sig.Address == address && sig.KeyIndex == keyIndex {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DecodeTransaction decodes the transaction and checks that its payload has not been modified.
+//
+// The argument previews and required signatures are checked against the decoded transaction,
+// so that they cannot be altered independently of it. If a payload hash is pinned, the payload
+// must also match it.
+func (p *PartiallySignedTransaction) DecodeTransaction() (*Transaction, error) {
+	tx, err := DecodeTransaction(p.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode partially signed transaction: %w", err)
+	}
+
+	if payloadHash(tx) != p.PayloadHash {
+		return nil, ErrPayloadMismatch
+	}
+
+	if p.expectedPayloadHash != "" && p.PayloadHash != p.expectedPayloadHash {
+		return nil, fmt.Errorf("%w: expected payload hash %s, got %s", ErrPayloadMismatch, p.expectedPayloadHash, p.PayloadHash)
+	}
+
+	err = p.checkMetadata(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// Verify pins the expected payload hash and checks the partially signed transaction against it.
+//
+// The expected hash must be obtained independently of the partially signed transaction,
+// for example from the party that created it.
+func (p *PartiallySignedTransaction) Verify(expectedPayloadHash string) error {
+	if expectedPayloadHash == "" {
+		return ErrPayloadNotVerified
+	}
+
+	p.expectedPayloadHash = expectedPayloadHash
+
+	_, err := p.DecodeTransaction()
+	return err
+}
+
+// checkMetadata checks that the argument previews and required signatures match the transaction.
+func (p *PartiallySignedTransaction) checkMetadata(tx *Transaction) error {
+	previews := argumentPreviews(tx)
+	if len(p.Arguments) != len(previews) {
+		return fmt.Errorf("%w: %d argument previews for %d arguments", ErrMetadataMismatch, len(p.Arguments), len(previews))
+	}
+
+	for i, preview := range previews {
+		if p.Arguments[i] != preview {
+			return fmt.Errorf("%w: preview of argument %d", ErrMetadataMismatch, i)
+		}
+	}
+
+	signers := tx.signerMap()
+	for _, required := range p.Signatures {
+		if _, ok := signers[required.Address]; !ok {
+			return fmt.Errorf("%w: %s is not a proposer, payer or authorizer", ErrMetadataMismatch, required.Address)
+		}
+
+		if role := signatureRole(tx, required.Address); required.Role != role {
+			return fmt.Errorf("%w: key %d of %s signs the %s, not the %s", ErrMetadataMismatch, required.KeyIndex, required.Address, role, required.Role)
+		}
+
+		signatures := tx.PayloadSignatures
+		if required.Role == SignatureRoleEnvelope {
+			signatures = tx.EnvelopeSignatures
+		}
+
+		if required.Signed != hasSignature(signatures, required.Address, required.KeyIndex) {
+			return fmt.Errorf("%w: signed state of key %d of %s", ErrMetadataMismatch, required.KeyIndex, required.Address)
+		}
+	}
+
+	return nil
+}
+
+// Sign signs the transaction with the given key in the role it is required for.
+//
+// The payload hash must be pinned, and each key can only sign once. The envelope can only be
+// signed once all required payload signatures are present, as the envelope signature covers
+// the payload signatures.
+func (p *PartiallySignedTransaction) Sign(address Address, keyIndex int, signer crypto.Signer) error {
+	if p.expectedPayloadHash == "" {
+		return ErrPayloadNotVerified
+	}
+
+	tx, err := p.DecodeTransaction()
+	if err != nil {
+		return err
+	}
+
+	required, ok := p.required(address, keyIndex)
+	if !ok {
+		return fmt.Errorf("%w: key %d of %s", ErrSignerNotRequired, keyIndex, address)
+	}
+
+	if required.Signed {
+		return fmt.Errorf("%w: key %d of %s", ErrAlreadySigned, keyIndex, address)
+	}
+
+	if required.Role == SignatureRoleEnvelope {
+		if missing := p.Missing(SignatureRolePayload); len(missing) > 0 {
+			return fmt.Errorf("%w: %d missing", ErrPayloadSignaturesIncomplete, len(missing))
+		}
+
+		err = tx.SignEnvelope(address, keyIndex, signer)
+	} else {
+		err = tx.SignPayload(address, keyIndex, signer)
+	}
+	if err != nil {
+		return err
+	}
+
+	p.update(tx)
+
+	return nil
+}
+
+func (p *PartiallySignedTransaction) required(address Address, keyIndex int) (RequiredSignature, bool) {
+	for _, required := range p.Signatures {
+		if required.Address == address && required.KeyIndex == keyIndex {
+			return required, true
+		}
+	}
+
+	return RequiredSignature{}, false
+}
+
+// Merge adds the signatures collected by another party to this partially signed transaction.
+//
+// The payload hash of this partially signed transaction must be pinned, and both transactions
+// must have the same payload. Payload signatures cannot be added once the envelope is signed,
+// and envelope signatures are only merged if both transactions carry the same payload
+// signatures, since otherwise they sign a different envelope.
+func (p *PartiallySignedTransaction) Merge(other *PartiallySignedTransaction) error {
+	if p.expectedPayloadHash == "" {
+		return ErrPayloadNotVerified
+	}
+
+	if other.PayloadHash != p.PayloadHash {
+		return ErrPayloadMismatch
+	}
+
+	tx, err := p.DecodeTransaction()
+	if err != nil {
+		return err
+	}
+
+	otherTx, err := other.DecodeTransaction()
+	if err != nil {
+		return err
+	}
+
+	for _, sig := range otherTx.PayloadSignatures {
+		if hasSignature(tx.PayloadSignatures, sig.Address, sig.KeyIndex) {
+			continue
+		}
+
+		if len(tx.EnvelopeSignatures) > 0 {
+			return fmt.Errorf("%w: cannot add payload signature of key %d of %s", ErrEnvelopeSigned, sig.KeyIndex, sig.Address)
+		}
+
+		tx.AddPayloadSignature(sig.Address, sig.KeyIndex, sig.Signature)
+	}
+
+	if len(otherTx.EnvelopeSignatures) > 0 {
+		if !bytes.Equal(tx.EnvelopeMessage(), otherTx.EnvelopeMessage()) {
+			return fmt.Errorf("%w: envelope signatures were made over different payload signatures", ErrPayloadMismatch)
+		}
+
+		for _, sig := range otherTx.EnvelopeSignatures {
+			if !hasSignature(tx.EnvelopeSignatures, sig.Address, sig.KeyIndex) {
+				tx.AddEnvelopeSignature(sig.Address, sig.KeyIndex, sig.Signature)
+			}
+		}
+	}
+
+	p.update(tx)
+
+	return nil
+}
+
+// Missing returns the required signatures of the given role that are not present yet.
+func (p *PartiallySignedTransaction) Missing(role SignatureRole) []RequiredSignature {
+	var missing []RequiredSignature
+	for _, required := range p.Signatures {
+		if required.Role == role && !required.Signed {
+			missing = append(missing, required)
+		}
+	}
+
+	return missing
+}
+
+// Complete returns true if all required signatures are present.
+func (p *PartiallySignedTransaction) Complete() bool {
+	return len(p.Missing(SignatureRolePayload)) == 0 && len(p.Missing(SignatureRoleEnvelope)) == 0
+}
+
+// Encode serializes the partially signed transaction to JSON.
+func (p *PartiallySignedTransaction) Encode() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// DecodePartiallySignedTransaction decodes a JSON-encoded partially signed transaction
+// and checks the integrity of its payload, argument previews and required signatures.
+//
+// The payload must match the expected payload hash, which is pinned for later signing rounds.
+func DecodePartiallySignedTransaction(b []byte, expectedPayloadHash string) (*PartiallySignedTransaction, error) {
+	var p PartiallySignedTransaction
+	err := json.Unmarshal(b, &p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode partially signed transaction: %w", err)
+	}
+
+	err = p.Verify(expectedPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,250 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestPartiallySignedTransaction(t *testing.T) {
+	newPartial := func(t *testing.T) (*flow.PartiallySignedTransaction, *signingAccount, *signingAccount) {
+		accounts := newSigningAccounts([]int{500, 500}, []int{1000})
+		authorizer, payer := accounts[0], accounts[1]
+
+		tx := flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(authorizer.account.Address, 0, 0).
+			SetPayer(payer.account.Address).
+			AddAuthorizer(authorizer.account.Address)
+
+		require.NoError(t, tx.AddArgument(cadence.String("hello")))
+
+		p, err := flow.NewPartiallySignedTransaction(
+			tx,
+			flow.SigningKey{Address: authorizer.account.Address, KeyIndex: 0},
+			flow.SigningKey{Address: authorizer.account.Address, KeyIndex: 1},
+			flow.SigningKey{Address: payer.account.Address, KeyIndex: 0},
+		)
+		require.NoError(t, err)
+
+		return p, authorizer, payer
+	}
+
+	// roundTrip simulates passing the partially signed transaction to another party.
+	roundTrip := func(t *testing.T, p *flow.PartiallySignedTransaction) *flow.PartiallySignedTransaction {
+		b, err := p.Encode()
+		require.NoError(t, err)
+
+		decoded, err := flow.DecodePartiallySignedTransaction(b, p.PayloadHash)
+		require.NoError(t, err)
+
+		return decoded
+	}
+
+	t.Run("Roles and previews", func(t *testing.T) {
+		p, _, _ := newPartial(t)
+
+		assert.Equal(t, []string{`"hello"`}, p.Arguments)
+		assert.Len(t, p.Missing(flow.SignatureRolePayload), 2)
+		assert.Len(t, p.Missing(flow.SignatureRoleEnvelope), 1)
+		assert.False(t, p.Complete())
+	})
+
+	t.Run("Not a signer", func(t *testing.T) {
+		tx := validTransaction()
+		_, err := flow.NewPartiallySignedTransaction(tx, flow.SigningKey{Address: flow.HexToAddress("01")})
+		assert.ErrorIs(t, err, flow.ErrSignerNotRequired)
+	})
+
+	t.Run("Merge signatures from several parties", func(t *testing.T) {
+		p, authorizer, payer := newPartial(t)
+
+		first := roundTrip(t, p)
+		require.NoError(t, first.Sign(authorizer.account.Address, 0, authorizer.signers[0]))
+
+		second := roundTrip(t, p)
+		require.NoError(t, second.Sign(authorizer.account.Address, 1, authorizer.signers[1]))
+
+		require.NoError(t, p.Merge(first))
+		require.NoError(t, p.Merge(second))
+		assert.Empty(t, p.Missing(flow.SignatureRolePayload))
+
+		p = roundTrip(t, p)
+		require.NoError(t, p.Sign(payer.account.Address, 0, payer.signers[0]))
+		assert.True(t, p.Complete())
+
+		tx, err := p.DecodeTransaction()
+		require.NoError(t, err)
+		assert.NoError(t, tx.VerifySignatures(lookupAccounts(authorizer, payer)))
+	})
+
+	t.Run("Envelope before payload", func(t *testing.T) {
+		p, _, payer := newPartial(t)
+
+		err := p.Sign(payer.account.Address, 0, payer.signers[0])
+		assert.ErrorIs(t, err, flow.ErrPayloadSignaturesIncomplete)
+	})
+
+	t.Run("Unknown key", func(t *testing.T) {
+		p, authorizer, _ := newPartial(t)
+
+		err := p.Sign(authorizer.account.Address, 5, authorizer.signers[0])
+		assert.ErrorIs(t, err, flow.ErrSignerNotRequired)
+	})
+
+	t.Run("Tampered payload", func(t *testing.T) {
+		p, authorizer, _ := newPartial(t)
+
+		tx, err := p.DecodeTransaction()
+		require.NoError(t, err)
+
+		tx.SetGasLimit(1)
+		p.Transaction = tx.Encode()
+
+		_, err = p.DecodeTransaction()
+		assert.ErrorIs(t, err, flow.ErrPayloadMismatch)
+		assert.ErrorIs(t, p.Sign(authorizer.account.Address, 0, authorizer.signers[0]), flow.ErrPayloadMismatch)
+
+		b, err := p.Encode()
+		require.NoError(t, err)
+		_, err = flow.DecodePartiallySignedTransaction(b, p.PayloadHash)
+		assert.ErrorIs(t, err, flow.ErrPayloadMismatch)
+	})
+
+	t.Run("Tampered payload with recomputed hash", func(t *testing.T) {
+		p, authorizer, _ := newPartial(t)
+		expectedHash := p.PayloadHash
+
+		tx, err := p.DecodeTransaction()
+		require.NoError(t, err)
+
+		tx.SetGasLimit(1)
+		p.Transaction = tx.Encode()
+		p.PayloadHash = hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(tx.PayloadMessage()))
+
+		b, err := p.Encode()
+		require.NoError(t, err)
+
+		_, err = flow.DecodePartiallySignedTransaction(b, expectedHash)
+		assert.ErrorIs(t, err, flow.ErrPayloadMismatch)
+
+		var received flow.PartiallySignedTransaction
+		require.NoError(t, json.Unmarshal(b, &received))
+		assert.ErrorIs(t, received.Sign(authorizer.account.Address, 0, authorizer.signers[0]), flow.ErrPayloadNotVerified)
+		assert.ErrorIs(t, received.Verify(expectedHash), flow.ErrPayloadMismatch)
+	})
+
+	t.Run("Sign twice", func(t *testing.T) {
+		p, authorizer, _ := newPartial(t)
+
+		require.NoError(t, p.Sign(authorizer.account.Address, 0, authorizer.signers[0]))
+
+		err := p.Sign(authorizer.account.Address, 0, authorizer.signers[0])
+		assert.ErrorIs(t, err, flow.ErrAlreadySigned)
+	})
+
+	t.Run("Merge payload signatures after envelope", func(t *testing.T) {
+		p, authorizer, payer := newPartial(t)
+
+		late := roundTrip(t, p)
+
+		require.NoError(t, p.Sign(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, p.Sign(authorizer.account.Address, 1, authorizer.signers[1]))
+		require.NoError(t, p.Sign(payer.account.Address, 0, payer.signers[0]))
+
+		// a payload signature by a key that is not required, made after the envelope was signed
+		tx, err := late.DecodeTransaction()
+		require.NoError(t, err)
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 2, authorizer.signers[0]))
+		late.Transaction = tx.Encode()
+
+		assert.ErrorIs(t, p.Merge(late), flow.ErrEnvelopeSigned)
+	})
+
+	t.Run("Tampered metadata", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			tamper func(p *flow.PartiallySignedTransaction)
+		}{
+			{
+				name: "Argument preview",
+				tamper: func(p *flow.PartiallySignedTransaction) {
+					p.Arguments[0] = `"goodbye"`
+				},
+			},
+			{
+				name: "Missing argument preview",
+				tamper: func(p *flow.PartiallySignedTransaction) {
+					p.Arguments = nil
+				},
+			},
+			{
+				name: "Signature role",
+				tamper: func(p *flow.PartiallySignedTransaction) {
+					p.Signatures[2].Role = flow.SignatureRolePayload
+				},
+			},
+			{
+				name: "Signed state",
+				tamper: func(p *flow.PartiallySignedTransaction) {
+					p.Signatures[0].Signed = true
+				},
+			},
+			{
+				name: "Unknown signer",
+				tamper: func(p *flow.PartiallySignedTransaction) {
+					p.Signatures[0].Address = flow.HexToAddress("ffffffffffffffff")
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				p, _, _ := newPartial(t)
+				tt.tamper(p)
+
+				b, err := p.Encode()
+				require.NoError(t, err)
+
+				_, err = flow.DecodePartiallySignedTransaction(b, p.PayloadHash)
+				assert.ErrorIs(t, err, flow.ErrMetadataMismatch)
+			})
+		}
+	})
+
+	t.Run("Merge different transaction", func(t *testing.T) {
+		p, _, _ := newPartial(t)
+
+		other, err := flow.NewPartiallySignedTransaction(validTransaction())
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, p.Merge(other), flow.ErrPayloadMismatch)
+	})
+}
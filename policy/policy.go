@@ -0,0 +1,200 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package policy provides a signer that only signs transactions allowed by a policy.
+//
+// Hot signers, such as the keys of a service that pays for user transactions, sign whatever
+// message they are given. The signer in this package decodes the message back into the
+// transaction it represents and checks the transaction against a policy before passing the
+// message on to the underlying signer.
+package policy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+var (
+	// ErrNotTransaction is returned when the message to sign is not a transaction payload or envelope.
+	ErrNotTransaction = errors.New("message is not a transaction payload or envelope")
+	// ErrPolicyViolation is returned when the transaction to sign is not allowed by the policy.
+	ErrPolicyViolation = errors.New("policy violation")
+)
+
+// An ArgumentConstraint checks the decoded arguments of a transaction.
+type ArgumentConstraint func(arguments []cadence.Value) error
+
+// A Policy describes the transactions a signer may sign.
+//
+// Empty fields do not restrict transactions.
+type Policy struct {
+	// ScriptHashes are the hashes of the allowed scripts, as returned by ScriptHash.
+	ScriptHashes []string
+	// MaxGasLimit is the maximum gas limit of a transaction.
+	MaxGasLimit uint64
+	// Payers are the allowed payers.
+	Payers []flow.Address
+	// Authorizers are the allowed authorizers. Every authorizer of a transaction must be allowed.
+	Authorizers []flow.Address
+	// Arguments are the constraints on the transaction arguments.
+	Arguments []ArgumentConstraint
+}
+
+// ScriptHash returns the hex-encoded SHA3-256 hash of a transaction script.
+func ScriptHash(script []byte) string {
+	return hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(script))
+}
+
+// Check returns an error wrapping ErrPolicyViolation if the transaction is not allowed by the policy.
+func (p Policy) Check(tx *flow.Transaction) error {
+	if len(p.ScriptHashes) > 0 && !containsString(p.ScriptHashes, ScriptHash(tx.Script)) {
+		return fmt.Errorf("%w: script with hash %s is not allowed", ErrPolicyViolation, ScriptHash(tx.Script))
+	}
+
+	if p.MaxGasLimit > 0 && tx.GasLimit > p.MaxGasLimit {
+		return fmt.Errorf("%w: gas limit %d exceeds maximum of %d", ErrPolicyViolation, tx.GasLimit, p.MaxGasLimit)
+	}
+
+	if len(p.Payers) > 0 && !containsAddress(p.Payers, tx.Payer) {
+		return fmt.Errorf("%w: payer %s is not allowed", ErrPolicyViolation, tx.Payer)
+	}
+
+	if len(p.Authorizers) > 0 {
+		for _, authorizer := range tx.Authorizers {
+			if !containsAddress(p.Authorizers, authorizer) {
+				return fmt.Errorf("%w: authorizer %s is not allowed", ErrPolicyViolation, authorizer)
+			}
+		}
+	}
+
+	if len(p.Arguments) > 0 {
+		arguments := make([]cadence.Value, len(tx.Arguments))
+		for i := range tx.Arguments {
+			value, err := tx.Argument(i, jsoncdc.WithAllowUnstructuredStaticTypes(true))
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrPolicyViolation, err)
+			}
+			arguments[i] = value
+		}
+
+		for _, constraint := range p.Arguments {
+			err := constraint(arguments)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrPolicyViolation, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MaxUFix64Argument returns a constraint that requires the argument at the given index to be a
+// UFix64 value of at most max.
+func MaxUFix64Argument(index int, max cadence.UFix64) ArgumentConstraint {
+	return func(arguments []cadence.Value) error {
+		if index >= len(arguments) {
+			return fmt.Errorf("missing argument %d", index)
+		}
+
+		value, ok := arguments[index].(cadence.UFix64)
+		if !ok {
+			return fmt.Errorf("argument %d is not a UFix64", index)
+		}
+
+		if value > max {
+			return fmt.Errorf("argument %d is %s, at most %s is allowed", index, value, max)
+		}
+
+		return nil
+	}
+}
+
+// AddressArgument returns a constraint that requires the argument at the given index to be one
+// of the given addresses.
+func AddressArgument(index int, addresses ...flow.Address) ArgumentConstraint {
+	return func(arguments []cadence.Value) error {
+		if index >= len(arguments) {
+			return fmt.Errorf("missing argument %d", index)
+		}
+
+		value, ok := arguments[index].(cadence.Address)
+		if !ok {
+			return fmt.Errorf("argument %d is not an Address", index)
+		}
+
+		if !containsAddress(addresses, flow.Address(value)) {
+			return fmt.Errorf("argument %d is address %s, which is not allowed", index, flow.Address(value))
+		}
+
+		return nil
+	}
+}
+
+// decodeMessage decodes a domain-tagged transaction payload or envelope message.
+//
+// The message must be exactly the canonical message of the decoded transaction, so that the
+// checked transaction is the one that is signed.
+func decodeMessage(message []byte) (*flow.Transaction, flow.SignatureRole, error) {
+	if !bytes.HasPrefix(message, flow.TransactionDomainTag[:]) {
+		return nil, "", fmt.Errorf("%w: missing transaction domain tag", ErrNotTransaction)
+	}
+	message = message[len(flow.TransactionDomainTag):]
+
+	tx, err := flow.DecodeTransaction(message)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrNotTransaction, err)
+	}
+
+	if bytes.Equal(message, tx.PayloadMessage()) {
+		return tx, flow.SignatureRolePayload, nil
+	}
+
+	if len(tx.EnvelopeSignatures) == 0 && bytes.Equal(message, tx.EnvelopeMessage()) {
+		return tx, flow.SignatureRoleEnvelope, nil
+	}
+
+	return nil, "", fmt.Errorf("%w: message is not in canonical form", ErrNotTransaction)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAddress(addresses []flow.Address, address flow.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,133 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+var (
+	_ crypto.ContextSigner = (*Signer)(nil)
+	_ crypto.HashingSigner = (*Signer)(nil)
+)
+
+// A Decision records whether a signer agreed to sign a message.
+type Decision struct {
+	// Transaction is the decoded transaction, or nil if the message is not a transaction.
+	Transaction *flow.Transaction
+	// Role is the part of the transaction that is signed.
+	Role flow.SignatureRole
+	// PublicKey is the public key of the signer.
+	PublicKey crypto.PublicKey
+	// Allowed is true if the message is signed.
+	Allowed bool
+	// Err is the reason the message is not signed.
+	Err error
+}
+
+// A Signer wraps a crypto.Signer and only signs transactions allowed by a policy.
+//
+// Every message is decoded into the transaction payload or envelope it represents, and
+// messages that are not transactions are refused. A Signer is safe for concurrent use
+// if the underlying signer is.
+type Signer struct {
+	signer     crypto.Signer
+	policy     Policy
+	onDecision func(Decision)
+}
+
+// An Option configures a Signer.
+type Option func(*Signer)
+
+// WithAuditHandler sets a function that is called with the decision for every message,
+// before the message is signed. The function may be called concurrently.
+func WithAuditHandler(handler func(Decision)) Option {
+	return func(s *Signer) {
+		s.onDecision = handler
+	}
+}
+
+// NewSigner creates a signer that signs with the given signer if the policy allows it.
+func NewSigner(signer crypto.Signer, policy Policy, opts ...Option) *Signer {
+	s := &Signer{
+		signer: signer,
+		policy: policy,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Sign signs the message if it is a transaction allowed by the policy.
+//
+// An error wrapping ErrNotTransaction or ErrPolicyViolation is returned otherwise.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	return s.SignWithContext(context.Background(), message)
+}
+
+// SignWithContext signs the message like Sign, passing the context to the underlying signer
+// if it is a crypto.ContextSigner.
+func (s *Signer) SignWithContext(ctx context.Context, message []byte) ([]byte, error) {
+	err := s.check(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.SignWithContext(ctx, s.signer, message)
+}
+
+func (s *Signer) check(message []byte) error {
+	tx, role, err := decodeMessage(message)
+	if err == nil {
+		err = s.policy.Check(tx)
+	}
+
+	if s.onDecision != nil {
+		s.onDecision(Decision{
+			Transaction: tx,
+			Role:        role,
+			PublicKey:   s.signer.PublicKey(),
+			Allowed:     err == nil,
+			Err:         err,
+		})
+	}
+
+	return err
+}
+
+// PublicKey returns the public key of the underlying signer.
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.signer.PublicKey()
+}
+
+// HashAlgorithm returns the hash algorithm of the underlying signer, or
+// crypto.UnknownHashAlgorithm if the signer does not report it.
+func (s *Signer) HashAlgorithm() crypto.HashAlgorithm {
+	if hashingSigner, ok := s.signer.(crypto.HashingSigner); ok {
+		return hashingSigner.HashAlgorithm()
+	}
+
+	return crypto.UnknownHashAlgorithm
+}
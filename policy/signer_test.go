@@ -0,0 +1,221 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/policy"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+const transferScript = `
+transaction(amount: UFix64, to: Address) {
+	prepare(signer: AuthAccount) {}
+}
+`
+
+func newTransaction(t *testing.T, amount string, to flow.Address) (*flow.Transaction, flow.Address, flow.Address) {
+	addresses := test.AddressGenerator()
+	authorizer, payer := addresses.New(), addresses.New()
+
+	value, err := cadence.NewUFix64(amount)
+	require.NoError(t, err)
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(transferScript)).
+		SetGasLimit(100).
+		SetReferenceBlockID(test.IdentifierGenerator().New()).
+		SetProposalKey(authorizer, 1, 0).
+		SetPayer(payer).
+		AddAuthorizer(authorizer)
+
+	require.NoError(t, tx.AddArgument(value))
+	require.NoError(t, tx.AddArgument(cadence.NewAddress(to)))
+
+	return tx, authorizer, payer
+}
+
+func TestSigner(t *testing.T) {
+	recipient := flow.HexToAddress("0x01")
+
+	newPolicy := func(authorizer, payer flow.Address) policy.Policy {
+		return policy.Policy{
+			ScriptHashes: []string{policy.ScriptHash([]byte(transferScript))},
+			MaxGasLimit:  1000,
+			Payers:       []flow.Address{payer},
+			Authorizers:  []flow.Address{authorizer},
+			Arguments: []policy.ArgumentConstraint{
+				policy.MaxUFix64Argument(0, 100_00000000),
+				policy.AddressArgument(1, recipient),
+			},
+		}
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction(t, "10.0", recipient)
+		key, signer := test.AccountKeyGenerator().NewWithSigner()
+
+		var decisions []policy.Decision
+		guard := policy.NewSigner(signer, newPolicy(authorizer, payer), policy.WithAuditHandler(func(d policy.Decision) {
+			decisions = append(decisions, d)
+		}))
+
+		require.NoError(t, tx.SignPayload(authorizer, key.Index, guard))
+		require.NoError(t, tx.SignEnvelope(payer, key.Index, guard))
+
+		require.Len(t, decisions, 2)
+		assert.True(t, decisions[0].Allowed)
+		assert.Equal(t, flow.SignatureRolePayload, decisions[0].Role)
+		assert.Equal(t, tx.PayloadMessage(), decisions[1].Transaction.PayloadMessage())
+		assert.Equal(t, flow.SignatureRoleEnvelope, decisions[1].Role)
+		assert.Equal(t, key.PublicKey, decisions[1].PublicKey)
+
+		accounts := map[flow.Address]*flow.Account{
+			authorizer: {Address: authorizer, Keys: []*flow.AccountKey{key}},
+			payer:      {Address: payer, Keys: []*flow.AccountKey{key}},
+		}
+		assert.NoError(t, tx.VerifySignatures(func(address flow.Address) (*flow.Account, error) {
+			return accounts[address], nil
+		}))
+	})
+
+	t.Run("Violations", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction(t, "10.0", recipient)
+		other := flow.HexToAddress("0x02")
+
+		tests := []struct {
+			name   string
+			modify func(p *policy.Policy, tx *flow.Transaction)
+		}{
+			{
+				name: "Script",
+				modify: func(_ *policy.Policy, tx *flow.Transaction) {
+					tx.SetScript([]byte(transferScript + "\n"))
+				},
+			},
+			{
+				name: "Gas limit",
+				modify: func(_ *policy.Policy, tx *flow.Transaction) {
+					tx.SetGasLimit(1001)
+				},
+			},
+			{
+				name: "Payer",
+				modify: func(_ *policy.Policy, tx *flow.Transaction) {
+					tx.SetPayer(other)
+				},
+			},
+			{
+				name: "Authorizer",
+				modify: func(_ *policy.Policy, tx *flow.Transaction) {
+					tx.AddAuthorizer(other)
+				},
+			},
+			{
+				name: "Amount",
+				modify: func(p *policy.Policy, _ *flow.Transaction) {
+					p.Arguments[0] = policy.MaxUFix64Argument(0, 5_00000000)
+				},
+			},
+			{
+				name: "Recipient",
+				modify: func(p *policy.Policy, _ *flow.Transaction) {
+					p.Arguments[1] = policy.AddressArgument(1, other)
+				},
+			},
+			{
+				name: "Argument type",
+				modify: func(p *policy.Policy, _ *flow.Transaction) {
+					p.Arguments[1] = policy.MaxUFix64Argument(1, 5_00000000)
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tx := *tx
+				p := newPolicy(authorizer, payer)
+				p.Arguments = append([]policy.ArgumentConstraint(nil), p.Arguments...)
+				tt.modify(&p, &tx)
+
+				_, signer := test.AccountKeyGenerator().NewWithSigner()
+
+				var decision policy.Decision
+				guard := policy.NewSigner(signer, p, policy.WithAuditHandler(func(d policy.Decision) {
+					decision = d
+				}))
+
+				err := tx.SignPayload(authorizer, 1, guard)
+				assert.ErrorIs(t, err, policy.ErrPolicyViolation)
+				assert.Empty(t, tx.PayloadSignatures)
+
+				assert.False(t, decision.Allowed)
+				assert.ErrorIs(t, decision.Err, policy.ErrPolicyViolation)
+				require.NotNil(t, decision.Transaction)
+				assert.Equal(t, tx.ID(), decision.Transaction.ID())
+			})
+		}
+	})
+
+	t.Run("Not a transaction", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction(t, "10.0", recipient)
+		_, signer := test.AccountKeyGenerator().NewWithSigner()
+
+		var decisions []policy.Decision
+		guard := policy.NewSigner(signer, newPolicy(authorizer, payer), policy.WithAuditHandler(func(d policy.Decision) {
+			decisions = append(decisions, d)
+		}))
+
+		_, err := flow.SignUserMessage(guard, tx.PayloadMessage())
+		assert.ErrorIs(t, err, policy.ErrNotTransaction)
+
+		_, err = guard.Sign(append(flow.TransactionDomainTag[:], []byte("not a transaction")...))
+		assert.ErrorIs(t, err, policy.ErrNotTransaction)
+
+		// a full transaction encoding is neither a payload nor an envelope message
+		_, err = guard.Sign(append(flow.TransactionDomainTag[:], tx.Encode()...))
+		assert.ErrorIs(t, err, policy.ErrNotTransaction)
+
+		require.Len(t, decisions, 3)
+		for _, decision := range decisions {
+			assert.False(t, decision.Allowed)
+			assert.Nil(t, decision.Transaction)
+		}
+	})
+
+	t.Run("Signing plan", func(t *testing.T) {
+		_, authorizer, payer := newTransaction(t, "10.0", recipient)
+		key, signer := test.AccountKeyGenerator().NewWithSigner()
+		guard := policy.NewSigner(signer, newPolicy(authorizer, payer))
+
+		assert.Equal(t, crypto.SHA3_256, guard.HashAlgorithm())
+
+		account := &flow.Account{Address: authorizer, Keys: []*flow.AccountKey{key}}
+		plan, err := flow.PlanSigningKeys(account, flow.NewKeySigners(guard))
+		require.NoError(t, err)
+		assert.Equal(t, []int{key.Index}, plan.KeyIndices())
+	})
+}
@@ -0,0 +1,304 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package proposer provides helpers for managing the proposal keys of an account.
+//
+// A proposal key must declare an up-to-date sequence number for every transaction it
+// proposes. The helpers in this package track those sequence numbers locally so that
+// many transactions can be submitted concurrently from the same account without
+// re-fetching the account before each submission.
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+var (
+	// ErrKeyNotFound is returned when the managed key does not exist on the account.
+	ErrKeyNotFound = errors.New("proposal key not found")
+	// ErrKeyRevoked is returned when the managed key has been revoked.
+	ErrKeyRevoked = errors.New("proposal key is revoked")
+)
+
+// AccountGetter is the subset of the access API required to load account keys.
+type AccountGetter interface {
+	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
+}
+
+// A KeyManager hands out sequence numbers for a single proposal key.
+//
+// The manager loads the current sequence number of the key from the chain and then
+// reserves consecutive sequence numbers for concurrent callers. Each reservation must
+// be resolved once the outcome of its transaction is known: a reservation is confirmed
+// if the sequence number was consumed on chain, or rolled back if the transaction never
+// made it into a block.
+//
+// If a transaction fails with a sequence number mismatch, the manager discards its
+// local state and resyncs with the chain before handing out the next reservation.
+//
+// A KeyManager is safe for concurrent use.
+type KeyManager struct {
+	client   AccountGetter
+	address  flow.Address
+	keyIndex int
+
+	mu         sync.Mutex
+	next       uint64
+	generation uint64
+	stale      bool
+}
+
+// NewKeyManager creates a key manager for the given account key and loads its
+// current sequence number from the chain.
+func NewKeyManager(
+	ctx context.Context,
+	client AccountGetter,
+	address flow.Address,
+	keyIndex int,
+) (*KeyManager, error) {
+	m := &KeyManager{
+		client:   client,
+		address:  address,
+		keyIndex: keyIndex,
+	}
+
+	err := m.Sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Address returns the address of the account that owns the managed key.
+func (m *KeyManager) Address() flow.Address {
+	return m.address
+}
+
+// KeyIndex returns the index of the managed key on its account.
+func (m *KeyManager) KeyIndex() int {
+	return m.keyIndex
+}
+
+// Sync reloads the sequence number of the key from the chain.
+//
+// All reservations handed out before the sync are invalidated; resolving them
+// afterwards has no effect.
+func (m *KeyManager) Sync(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.syncLocked(ctx)
+}
+
+func (m *KeyManager) syncLocked(ctx context.Context) error {
+	account, err := m.client.GetAccountAtLatestBlock(ctx, m.address)
+	if err != nil {
+		return fmt.Errorf("proposer: failed to fetch account %s: %w", m.address, err)
+	}
+
+	key, err := findKey(account, m.keyIndex)
+	if err != nil {
+		return err
+	}
+
+	m.next = key.SequenceNumber
+	m.generation++
+	m.stale = false
+
+	return nil
+}
+
+// Reserve reserves the next sequence number of the key.
+//
+// If a previous reservation reported a sequence number mismatch, the key is resynced
+// with the chain before the reservation is made.
+func (m *KeyManager) Reserve(ctx context.Context) (*Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stale {
+		err := m.syncLocked(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r := &Reservation{
+		manager:        m,
+		generation:     m.generation,
+		sequenceNumber: m.next,
+	}
+	m.next++
+
+	return r, nil
+}
+
+func (m *KeyManager) rollback(r *Reservation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r.generation != m.generation {
+		return
+	}
+
+	if r.sequenceNumber == m.next-1 {
+		m.next--
+		return
+	}
+
+	// a later reservation is still outstanding, so the released sequence number
+	// leaves a gap that can only be repaired by reading the chain state again
+	m.stale = true
+}
+
+func (m *KeyManager) invalidate(r *Reservation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r.generation != m.generation {
+		return
+	}
+
+	m.stale = true
+}
+
+// A Reservation is a sequence number reserved for a single transaction.
+type Reservation struct {
+	manager        *KeyManager
+	generation     uint64
+	sequenceNumber uint64
+
+	once    sync.Once
+	settled uint32
+}
+
+// SequenceNumber returns the reserved sequence number.
+func (r *Reservation) SequenceNumber() uint64 {
+	return r.sequenceNumber
+}
+
+// ProposalKey returns the proposal key declaring the reserved sequence number.
+func (r *Reservation) ProposalKey() flow.ProposalKey {
+	return flow.ProposalKey{
+		Address:        r.manager.address,
+		KeyIndex:       r.manager.keyIndex,
+		SequenceNumber: r.sequenceNumber,
+	}
+}
+
+// SetProposalKey sets the reserved proposal key on the given transaction.
+func (r *Reservation) SetProposalKey(tx *flow.Transaction) *flow.Transaction {
+	return tx.SetProposalKey(r.manager.address, r.manager.keyIndex, r.sequenceNumber)
+}
+
+// Confirm marks the reserved sequence number as consumed on chain.
+func (r *Reservation) Confirm() {
+	r.settle(func() {})
+}
+
+// Rollback returns the reserved sequence number to the manager.
+//
+// Rollback must only be called if the transaction never reached the chain, for example
+// if the submission was rejected or the transaction expired.
+func (r *Reservation) Rollback() {
+	r.settle(func() {
+		r.manager.rollback(r)
+	})
+}
+
+// settle runs f if the reservation has not been confirmed, rolled back or invalidated yet.
+func (r *Reservation) settle(f func()) {
+	r.once.Do(func() {
+		f()
+		atomic.StoreUint32(&r.settled, 1)
+	})
+}
+
+func (r *Reservation) isSettled() bool {
+	return atomic.LoadUint32(&r.settled) == 1
+}
+
+// Resolve confirms or rolls back the reservation based on the outcome of its transaction.
+//
+// The submission error takes precedence over the result. A sequence number mismatch in
+// either of them causes the manager to resync with the chain. Results that are not yet
+// final (i.e. not executed, sealed or expired) leave the reservation outstanding.
+func (r *Reservation) Resolve(result *flow.TransactionResult, err error) {
+	if err != nil {
+		if isSequenceNumberMismatch(err) {
+			r.settle(func() {
+				r.manager.invalidate(r)
+			})
+			return
+		}
+
+		r.Rollback()
+		return
+	}
+
+	if result == nil {
+		r.Rollback()
+		return
+	}
+
+	switch result.Status {
+	case flow.TransactionStatusExpired:
+		r.Rollback()
+	case flow.TransactionStatusExecuted, flow.TransactionStatusSealed:
+		if result.Error != nil && isSequenceNumberMismatch(result.Error) {
+			r.settle(func() {
+				r.manager.invalidate(r)
+			})
+			return
+		}
+
+		r.Confirm()
+	}
+}
+
+func findKey(account *flow.Account, keyIndex int) (*flow.AccountKey, error) {
+	for _, key := range account.Keys {
+		if key.Index != keyIndex {
+			continue
+		}
+
+		if key.Revoked {
+			return nil, fmt.Errorf("%w: key %d on account %s", ErrKeyRevoked, keyIndex, account.Address)
+		}
+
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: key %d on account %s", ErrKeyNotFound, keyIndex, account.Address)
+}
+
+func isSequenceNumberMismatch(err error) bool {
+	if flow.HasErrorCode(err, flow.ErrorCodeInvalidProposalSeqNumber) {
+		return true
+	}
+
+	// submission errors are reported by the access API as plain status errors
+	return flow.ParseTransactionExecutionError(err.Error()).HasCode(flow.ErrorCodeInvalidProposalSeqNumber)
+}
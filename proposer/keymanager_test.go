@@ -0,0 +1,274 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proposer_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/proposer"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	_ proposer.AccountGetter = (*grpc.Client)(nil)
+	_ proposer.AccountGetter = (*http.Client)(nil)
+)
+
+type mockAccountGetter struct {
+	mu      sync.Mutex
+	account *flow.Account
+	calls   int
+}
+
+func newMockAccountGetter() *mockAccountGetter {
+	account := test.AccountGenerator().New()
+	for i, key := range account.Keys {
+		key.Index = i
+		key.SequenceNumber = 0
+	}
+
+	return &mockAccountGetter{account: account}
+}
+
+func (m *mockAccountGetter) GetAccountAtLatestBlock(_ context.Context, address flow.Address) (*flow.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+
+	if address != m.account.Address {
+		return nil, errors.New("account not found")
+	}
+
+	keys := make([]*flow.AccountKey, len(m.account.Keys))
+	for i, key := range m.account.Keys {
+		k := *key
+		keys[i] = &k
+	}
+
+	account := *m.account
+	account.Keys = keys
+
+	return &account, nil
+}
+
+func (m *mockAccountGetter) setSequenceNumber(keyIndex int, seq uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.account.Keys[keyIndex].SequenceNumber = seq
+}
+
+func TestKeyManager_Reserve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Loads sequence number from chain", func(t *testing.T) {
+		client := newMockAccountGetter()
+		client.setSequenceNumber(1, 42)
+
+		m, err := proposer.NewKeyManager(ctx, client, client.account.Address, 1)
+		require.NoError(t, err)
+
+		r, err := m.Reserve(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(42), r.SequenceNumber())
+		assert.Equal(t, flow.ProposalKey{
+			Address:        client.account.Address,
+			KeyIndex:       1,
+			SequenceNumber: 42,
+		}, r.ProposalKey())
+
+		tx := r.SetProposalKey(flow.NewTransaction())
+		assert.Equal(t, r.ProposalKey(), tx.ProposalKey)
+	})
+
+	t.Run("Unique under concurrency", func(t *testing.T) {
+		client := newMockAccountGetter()
+
+		m, err := proposer.NewKeyManager(ctx, client, client.account.Address, 0)
+		require.NoError(t, err)
+
+		const count = 100
+
+		var wg sync.WaitGroup
+		seqs := make(chan uint64, count)
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r, err := m.Reserve(ctx)
+				if assert.NoError(t, err) {
+					seqs <- r.SequenceNumber()
+				}
+			}()
+		}
+		wg.Wait()
+		close(seqs)
+
+		seen := make(map[uint64]struct{})
+		for seq := range seqs {
+			seen[seq] = struct{}{}
+		}
+
+		assert.Len(t, seen, count)
+	})
+
+	t.Run("Missing key", func(t *testing.T) {
+		client := newMockAccountGetter()
+
+		_, err := proposer.NewKeyManager(ctx, client, client.account.Address, 5)
+		assert.ErrorIs(t, err, proposer.ErrKeyNotFound)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		client := newMockAccountGetter()
+		client.account.Keys[0].Revoked = true
+
+		_, err := proposer.NewKeyManager(ctx, client, client.account.Address, 0)
+		assert.ErrorIs(t, err, proposer.ErrKeyRevoked)
+	})
+}
+
+func TestReservation_Resolve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Confirmed on sealed result", func(t *testing.T) {
+		client := newMockAccountGetter()
+		m, err := proposer.NewKeyManager(ctx, client, client.account.Address, 0)
+		require.NoError(t, err)
+
+		r, err := m.Reserve(ctx)
+		require.NoError(t, err)
+
+		r.Resolve(&flow.TransactionResult{
+			Status: flow.TransactionStatusSealed,
+			Error:  errors.New("[Error Code: 1101] cadence runtime error"),
+		}, nil)
+
+		next, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), next.SequenceNumber())
+	})
+
+	t.Run("Rolled back on submission error", func(t *testing.T) {
+		client := newMockAccountGetter()
+		m, err := proposer.NewKeyManager(ctx, client, client.account.Address, 0)
+		require.NoError(t, err)
+
+		r, err := m.Reserve(ctx)
+		require.NoError(t, err)
+
+		r.Resolve(nil, errors.New("connection refused"))
+
+		next, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), next.SequenceNumber())
+	})
+
+	t.Run("Rolled back on expiry", func(t *testing.T) {
+		client := newMockAccountGetter()
+		m, err := proposer.NewKeyManager(ctx, client, client.account.Address, 0)
+		require.NoError(t, err)
+
+		r, err := m.Reserve(ctx)
+		require.NoError(t, err)
+
+		r.Resolve(&flow.TransactionResult{Status: flow.TransactionStatusExpired}, nil)
+		// resolving twice has no effect
+		r.Rollback()
+
+		next, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), next.SequenceNumber())
+	})
+
+	t.Run("Gap triggers resync", func(t *testing.T) {
+		client := newMockAccountGetter()
+		m, err := proposer.NewKeyManager(ctx, client, client.account.Address, 0)
+		require.NoError(t, err)
+
+		first, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		second, err := m.Reserve(ctx)
+		require.NoError(t, err)
+
+		first.Rollback()
+		second.Confirm()
+
+		client.setSequenceNumber(0, 0)
+
+		next, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), next.SequenceNumber())
+		assert.Equal(t, 2, client.calls)
+	})
+
+	t.Run("Resync on sequence number mismatch", func(t *testing.T) {
+		client := newMockAccountGetter()
+		m, err := proposer.NewKeyManager(ctx, client, client.account.Address, 0)
+		require.NoError(t, err)
+
+		r, err := m.Reserve(ctx)
+		require.NoError(t, err)
+
+		client.setSequenceNumber(0, 7)
+
+		r.Resolve(&flow.TransactionResult{
+			Status: flow.TransactionStatusSealed,
+			Error:  errors.New("[Error Code: 1007] invalid proposal key: public key 0 has sequence number 7, but given 0"),
+		}, nil)
+
+		next, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), next.SequenceNumber())
+
+		// resolving a reservation from before the resync has no effect
+		r.Rollback()
+
+		after, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8), after.SequenceNumber())
+	})
+
+	t.Run("Pending result leaves reservation outstanding", func(t *testing.T) {
+		client := newMockAccountGetter()
+		m, err := proposer.NewKeyManager(ctx, client, client.account.Address, 0)
+		require.NoError(t, err)
+
+		r, err := m.Reserve(ctx)
+		require.NoError(t, err)
+
+		r.Resolve(&flow.TransactionResult{Status: flow.TransactionStatusPending}, nil)
+		r.Rollback()
+
+		next, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), next.SequenceNumber())
+	})
+}
@@ -0,0 +1,300 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// ErrNoUsableKeys is returned when an account has no non-revoked key matching any of the provided signers.
+var ErrNoUsableKeys = errors.New("no usable proposal keys")
+
+// A Pool rotates transaction proposals across the keys of a single account.
+//
+// Each key in the pool is paired with a signer and proposes at most one transaction
+// at a time, so an account with N keys can have N transactions in flight in parallel.
+// Keys are handed out in round-robin order as they become available.
+//
+// A Pool is safe for concurrent use.
+type Pool struct {
+	address   flow.Address
+	keys      []*poolKey
+	available chan *poolKey
+}
+
+type poolKey struct {
+	manager *KeyManager
+	signer  crypto.Signer
+}
+
+// NewPool creates a proposer pool for the given account.
+//
+// The account is fetched once and every non-revoked key whose public key matches one of
+// the provided signers is added to the pool. A signer may match several keys, which is the
+// case when the same public key is added to the account multiple times. Signers are not
+// assumed to be safe for concurrent use, so the signatures made by leases of keys that share
+// a signer are serialized.
+func NewPool(
+	ctx context.Context,
+	client AccountGetter,
+	address flow.Address,
+	signers ...crypto.Signer,
+) (*Pool, error) {
+	account, err := client.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("proposer: failed to fetch account %s: %w", address, err)
+	}
+
+	// each provided signer is wrapped once, so that all keys sharing it share its lock
+	serialSigners := make([]*serialSigner, len(signers))
+	for i, signer := range signers {
+		serialSigners[i] = &serialSigner{signer: signer}
+	}
+
+	keys := make([]*poolKey, 0, len(account.Keys))
+	for _, key := range account.Keys {
+		if key.Revoked {
+			continue
+		}
+
+		i := matchSigner(key, signers)
+		if i < 0 {
+			continue
+		}
+
+		keys = append(keys, &poolKey{
+			manager: &KeyManager{
+				client:     client,
+				address:    address,
+				keyIndex:   key.Index,
+				next:       key.SequenceNumber,
+				generation: 1,
+			},
+			signer: serialSigners[i],
+		})
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: account %s", ErrNoUsableKeys, address)
+	}
+
+	available := make(chan *poolKey, len(keys))
+	for _, key := range keys {
+		available <- key
+	}
+
+	return &Pool{
+		address:   address,
+		keys:      keys,
+		available: available,
+	}, nil
+}
+
+// matchSigner returns the index of the signer for the given key, or -1 if no signer matches.
+func matchSigner(key *flow.AccountKey, signers []crypto.Signer) int {
+	for i, signer := range signers {
+		publicKey := signer.PublicKey()
+		if publicKey != nil && key.PublicKey != nil && publicKey.Equals(key.PublicKey) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Address returns the address of the account the pool proposes for.
+func (p *Pool) Address() flow.Address {
+	return p.address
+}
+
+// Size returns the number of keys in the pool, which is also the maximum number
+// of transactions that can be in flight at the same time.
+func (p *Pool) Size() int {
+	return len(p.keys)
+}
+
+// Acquire waits until a key is available and reserves its next sequence number.
+//
+// The returned lease must be released once the outcome of the transaction is known,
+// otherwise the key is never returned to the pool.
+func (p *Pool) Acquire(ctx context.Context) (*Lease, error) {
+	var key *poolKey
+
+	select {
+	case key = <-p.available:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	reservation, err := key.manager.Reserve(ctx)
+	if err != nil {
+		p.available <- key
+		return nil, err
+	}
+
+	return &Lease{
+		pool:        p,
+		key:         key,
+		reservation: reservation,
+	}, nil
+}
+
+// A Lease grants exclusive use of a pool key for proposing a single transaction.
+type Lease struct {
+	pool        *Pool
+	key         *poolKey
+	reservation *Reservation
+
+	mu       sync.Mutex
+	released bool
+}
+
+// KeyIndex returns the index of the leased key.
+func (l *Lease) KeyIndex() int {
+	return l.key.manager.keyIndex
+}
+
+// Signer returns the signer paired with the leased key.
+//
+// Signatures made with the returned signer are serialized with those of other leases whose
+// keys share the same signer.
+func (l *Lease) Signer() crypto.Signer {
+	return l.key.signer
+}
+
+// ProposalKey returns the proposal key declaring the reserved sequence number.
+func (l *Lease) ProposalKey() flow.ProposalKey {
+	return l.reservation.ProposalKey()
+}
+
+// SetProposalKey sets the leased proposal key on the given transaction.
+func (l *Lease) SetProposalKey(tx *flow.Transaction) *flow.Transaction {
+	return l.reservation.SetProposalKey(tx)
+}
+
+// SignPayload signs the transaction payload with the leased key.
+//
+// This is only needed if the pool account is not also the payer of the transaction.
+func (l *Lease) SignPayload(tx *flow.Transaction) error {
+	return tx.SignPayload(l.pool.address, l.KeyIndex(), l.key.signer)
+}
+
+// SignEnvelope signs the transaction envelope with the leased key.
+//
+// This is only needed if the pool account is also the payer of the transaction.
+func (l *Lease) SignEnvelope(tx *flow.Transaction) error {
+	return tx.SignEnvelope(l.pool.address, l.KeyIndex(), l.key.signer)
+}
+
+// Release resolves the reserved sequence number based on the outcome of the
+// transaction and returns the key to the pool.
+//
+// The key is only returned once the reservation is settled. If the result is not final yet,
+// the key stays leased, since its next transaction could otherwise be proposed with a stale
+// sequence number, and Release must be called again once the outcome is known. Release
+// reports whether the key was returned to the pool.
+//
+// See Reservation.Resolve for how the result and error are interpreted.
+func (l *Lease) Release(result *flow.TransactionResult, err error) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.released {
+		return true
+	}
+
+	l.reservation.Resolve(result, err)
+	if !l.reservation.isSettled() {
+		return false
+	}
+
+	l.released = true
+	l.pool.available <- l.key
+
+	return true
+}
+
+// ProvisionKeys generates the transactions that add count copies of the given key to an account.
+//
+// Each transaction adds a single key using templates.AddAccountKey and must be proposed, signed and
+// submitted by an existing key of the account.
+func ProvisionKeys(address flow.Address, key *flow.AccountKey, count int) ([]*flow.Transaction, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("proposer: key count must be positive, got %d", count)
+	}
+
+	txs := make([]*flow.Transaction, count)
+	for i := range txs {
+		tx, err := templates.AddAccountKey(address, key)
+		if err != nil {
+			return nil, err
+		}
+
+		txs[i] = tx
+	}
+
+	return txs, nil
+}
+
+// A serialSigner serializes the signatures of a signer shared by several pool keys, since
+// signers such as crypto.InMemorySigner are not safe for concurrent use.
+type serialSigner struct {
+	mu     sync.Mutex
+	signer crypto.Signer
+}
+
+var (
+	_ crypto.ContextSigner = (*serialSigner)(nil)
+	_ crypto.HashingSigner = (*serialSigner)(nil)
+)
+
+func (s *serialSigner) Sign(message []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.signer.Sign(message)
+}
+
+func (s *serialSigner) SignWithContext(ctx context.Context, message []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return crypto.SignWithContext(ctx, s.signer, message)
+}
+
+func (s *serialSigner) PublicKey() crypto.PublicKey {
+	return s.signer.PublicKey()
+}
+
+// HashAlgorithm returns the hash algorithm of the wrapped signer, or
+// crypto.UnknownHashAlgorithm if it does not report one.
+func (s *serialSigner) HashAlgorithm() crypto.HashAlgorithm {
+	if hashingSigner, ok := s.signer.(crypto.HashingSigner); ok {
+		return hashingSigner.HashAlgorithm()
+	}
+
+	return crypto.UnknownHashAlgorithm
+}
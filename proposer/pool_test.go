@@ -0,0 +1,277 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proposer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/proposer"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func newPoolAccount(count int) (*mockAccountGetter, []crypto.Signer) {
+	keys := test.AccountKeyGenerator()
+	account := &flow.Account{
+		Address: test.AddressGenerator().New(),
+	}
+
+	signers := make([]crypto.Signer, count)
+	for i := 0; i < count; i++ {
+		key, signer := keys.NewWithSigner()
+		key.Index = i
+		key.SequenceNumber = uint64(i * 10)
+		account.Keys = append(account.Keys, key)
+		signers[i] = signer
+	}
+
+	return &mockAccountGetter{account: account}, signers
+}
+
+func TestPool_Acquire(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rotates across keys", func(t *testing.T) {
+		client, signers := newPoolAccount(3)
+
+		pool, err := proposer.NewPool(ctx, client, client.account.Address, signers...)
+		require.NoError(t, err)
+		assert.Equal(t, 3, pool.Size())
+
+		leases := make([]*proposer.Lease, 3)
+		for i := range leases {
+			leases[i], err = pool.Acquire(ctx)
+			require.NoError(t, err)
+
+			assert.Equal(t, i, leases[i].KeyIndex())
+			assert.Equal(t, uint64(i*10), leases[i].ProposalKey().SequenceNumber)
+			assert.True(t, leases[i].Signer().PublicKey().Equals(signers[i].PublicKey()))
+		}
+
+		leases[1].Release(&flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil)
+
+		next, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, next.KeyIndex())
+		assert.Equal(t, uint64(11), next.ProposalKey().SequenceNumber)
+	})
+
+	t.Run("Skips revoked and unmatched keys", func(t *testing.T) {
+		client, signers := newPoolAccount(3)
+		client.account.Keys[0].Revoked = true
+
+		pool, err := proposer.NewPool(ctx, client, client.account.Address, signers[0], signers[1])
+		require.NoError(t, err)
+		assert.Equal(t, 1, pool.Size())
+
+		lease, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, lease.KeyIndex())
+	})
+
+	t.Run("No usable keys", func(t *testing.T) {
+		client, _ := newPoolAccount(2)
+
+		keys := test.AccountKeyGenerator()
+		keys.New()
+		keys.New()
+		_, other := keys.NewWithSigner()
+
+		_, err := proposer.NewPool(ctx, client, client.account.Address, other)
+		assert.ErrorIs(t, err, proposer.ErrNoUsableKeys)
+	})
+
+	t.Run("Blocks until a key is released", func(t *testing.T) {
+		client, signers := newPoolAccount(1)
+
+		pool, err := proposer.NewPool(ctx, client, client.account.Address, signers...)
+		require.NoError(t, err)
+
+		lease, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err = pool.Acquire(timeoutCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		lease.Release(nil, assert.AnError)
+
+		next, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, lease.ProposalKey(), next.ProposalKey())
+	})
+}
+
+func TestLease_Release(t *testing.T) {
+	ctx := context.Background()
+	client, signers := newPoolAccount(1)
+
+	pool, err := proposer.NewPool(ctx, client, client.account.Address, signers...)
+	require.NoError(t, err)
+
+	lease, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+
+	assert.False(t, lease.Release(&flow.TransactionResult{Status: flow.TransactionStatusPending}, nil))
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	_, err = pool.Acquire(timeoutCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.True(t, lease.Release(&flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil))
+	assert.True(t, lease.Release(&flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil))
+
+	next, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, lease.ProposalKey().SequenceNumber+1, next.ProposalKey().SequenceNumber)
+}
+
+func TestLease_ReleaseConcurrently(t *testing.T) {
+	ctx := context.Background()
+	client, signers := newPoolAccount(1)
+
+	pool, err := proposer.NewPool(ctx, client, client.account.Address, signers...)
+	require.NoError(t, err)
+
+	lease, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lease.Release(&flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil)
+		}()
+	}
+	wg.Wait()
+
+	_, err = pool.Acquire(ctx)
+	require.NoError(t, err)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	// the key must only have been returned to the pool once
+	_, err = pool.Acquire(timeoutCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLease_SignConcurrently(t *testing.T) {
+	ctx := context.Background()
+
+	// every key of the account has the same public key, driven by a single signer
+	client, signers := newPoolAccount(1)
+	for i := 1; i < 4; i++ {
+		key := *client.account.Keys[0]
+		key.Index = i
+		client.account.Keys = append(client.account.Keys, &key)
+	}
+
+	signer := signers[0].(crypto.InMemorySigner)
+	hasher := &test.ExclusiveHasher{Hasher: signer.Hasher}
+	signer.Hasher = hasher
+
+	pool, err := proposer.NewPool(ctx, client, client.account.Address, signer)
+	require.NoError(t, err)
+	require.Equal(t, 4, pool.Size())
+
+	lookup := func(flow.Address) (*flow.Account, error) {
+		return client.account, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < pool.Size(); i++ {
+		lease, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 5; j++ {
+				tx := flow.NewTransaction().
+					SetScript(test.GreetingScript).
+					SetGasLimit(uint64(j + 1)).
+					SetPayer(pool.Address())
+				lease.SetProposalKey(tx)
+
+				assert.NoError(t, lease.SignEnvelope(tx))
+				assert.NoError(t, tx.VerifySignatures(lookup))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, hasher.UsedConcurrently())
+}
+
+func TestLease_Sign(t *testing.T) {
+	ctx := context.Background()
+	client, signers := newPoolAccount(1)
+
+	pool, err := proposer.NewPool(ctx, client, client.account.Address, signers...)
+	require.NoError(t, err)
+
+	lease, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+
+	tx := flow.NewTransaction().
+		SetScript(test.GreetingScript).
+		SetPayer(pool.Address())
+	lease.SetProposalKey(tx)
+
+	require.NoError(t, lease.SignEnvelope(tx))
+	require.Len(t, tx.EnvelopeSignatures, 1)
+	assert.Equal(t, pool.Address(), tx.EnvelopeSignatures[0].Address)
+	assert.Equal(t, lease.KeyIndex(), tx.EnvelopeSignatures[0].KeyIndex)
+}
+
+func TestProvisionKeys(t *testing.T) {
+	address := test.AddressGenerator().New()
+	key := test.AccountKeyGenerator().New()
+
+	txs, err := proposer.ProvisionKeys(address, key, 3)
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+
+	expected, err := templates.AddAccountKey(address, key)
+	require.NoError(t, err)
+
+	for _, tx := range txs {
+		assert.Equal(t, expected.Script, tx.Script)
+		assert.Equal(t, expected.Arguments, tx.Arguments)
+		assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+	}
+
+	_, err = proposer.ProvisionKeys(address, key, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,231 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package reference provides a background-refreshed reference block for transactions.
+//
+// Every transaction must reference a recent block, which determines when the transaction
+// expires. Instead of fetching the latest block for each transaction, a Provider keeps a
+// recent block header refreshed in the background and shares it between all callers.
+package reference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+const (
+	// DefaultRefreshInterval is the default interval between reference block refreshes.
+	DefaultRefreshInterval = 5 * time.Second
+	// DefaultMaxAge is the default maximum time since the last successful refresh
+	// after which the reference block is considered stale.
+	DefaultMaxAge = time.Minute
+)
+
+var (
+	// ErrNotReady is returned when no reference block has been fetched yet.
+	ErrNotReady = errors.New("reference block not available yet")
+	// ErrStale is returned when the reference block has not been refreshed within the maximum age.
+	ErrStale = errors.New("reference block is stale")
+)
+
+// HeaderGetter is the subset of the access API required to fetch the latest block header.
+type HeaderGetter interface {
+	GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error)
+}
+
+// A Reference is a block that can be referenced by transactions.
+type Reference struct {
+	ID        flow.Identifier
+	Height    uint64
+	Timestamp time.Time
+}
+
+// ExpiryHeight returns the height of the last block that is allowed to include
+// a transaction referencing this block.
+func (r Reference) ExpiryHeight() uint64 {
+	return r.Height + flow.DefaultTransactionExpiry
+}
+
+// A Provider keeps a recent reference block refreshed in the background.
+//
+// A Provider is safe for concurrent use.
+type Provider struct {
+	client   HeaderGetter
+	sealed   bool
+	interval time.Duration
+	maxAge   time.Duration
+
+	mu        sync.RWMutex
+	current   Reference
+	updatedAt time.Time
+	lastErr   error
+}
+
+// An Option configures a Provider.
+type Option func(*Provider)
+
+// WithSealed makes the provider reference the latest sealed block instead of the latest finalized block.
+func WithSealed() Option {
+	return func(p *Provider) {
+		p.sealed = true
+	}
+}
+
+// WithRefreshInterval sets the interval between reference block refreshes.
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(p *Provider) {
+		p.interval = interval
+	}
+}
+
+// WithMaxAge sets the maximum time since the last successful refresh after which
+// the reference block is no longer handed out.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(p *Provider) {
+		p.maxAge = maxAge
+	}
+}
+
+// NewProvider creates a new reference block provider.
+//
+// The provider does not fetch any block until Start or Refresh is called.
+func NewProvider(client HeaderGetter, opts ...Option) *Provider {
+	p := &Provider{
+		client:   client,
+		interval: DefaultRefreshInterval,
+		maxAge:   DefaultMaxAge,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Start fetches the initial reference block and keeps refreshing it in the background
+// until the context is cancelled.
+//
+// An error is returned if the initial reference block cannot be fetched.
+func (p *Provider) Start(ctx context.Context) error {
+	err := p.Refresh(ctx)
+	if err != nil {
+		return err
+	}
+
+	go p.run(ctx)
+
+	return nil
+}
+
+func (p *Provider) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// errors are recorded and surfaced once the reference becomes stale
+			_ = p.Refresh(ctx)
+		}
+	}
+}
+
+// Refresh fetches the latest block header and makes it the current reference block.
+//
+// A header lower than the current reference, as returned by a lagging access node, is ignored
+// and does not count as a refresh, so the current reference still becomes stale if no newer
+// header is fetched within the maximum age.
+func (p *Provider) Refresh(ctx context.Context) error {
+	header, err := p.client.GetLatestBlockHeader(ctx, p.sealed)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err != nil {
+		p.lastErr = err
+		return fmt.Errorf("reference: failed to fetch latest block header: %w", err)
+	}
+
+	p.lastErr = nil
+
+	if header.Height < p.current.Height && p.current.ID != flow.EmptyID {
+		return nil
+	}
+
+	p.current = Reference{
+		ID:        header.ID,
+		Height:    header.Height,
+		Timestamp: header.Timestamp,
+	}
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+// Current returns the current reference block.
+//
+// ErrNotReady is returned if no block has been fetched yet, and ErrStale if the
+// reference block has not been refreshed within the maximum age.
+func (p *Provider) Current() (Reference, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.updatedAt.IsZero() {
+		return Reference{}, ErrNotReady
+	}
+
+	if age := time.Since(p.updatedAt); age > p.maxAge {
+		if p.lastErr != nil {
+			return Reference{}, fmt.Errorf("%w: last refresh %s ago: %s", ErrStale, age, p.lastErr)
+		}
+		return Reference{}, fmt.Errorf("%w: last refresh %s ago", ErrStale, age)
+	}
+
+	return p.current, nil
+}
+
+// ReferenceBlockID returns the ID of the current reference block.
+func (p *Provider) ReferenceBlockID() (flow.Identifier, error) {
+	ref, err := p.Current()
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	return ref.ID, nil
+}
+
+// SetReferenceBlock sets the current reference block on the transaction and returns it,
+// so that the caller can record the expiry height of the transaction.
+func (p *Provider) SetReferenceBlock(tx *flow.Transaction) (Reference, error) {
+	ref, err := p.Current()
+	if err != nil {
+		return Reference{}, err
+	}
+
+	tx.SetReferenceBlockID(ref.ID)
+
+	return ref, nil
+}
@@ -0,0 +1,188 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reference_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/reference"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	_ reference.HeaderGetter = (*grpc.Client)(nil)
+	_ reference.HeaderGetter = (*http.Client)(nil)
+)
+
+type mockHeaderGetter struct {
+	mu       sync.Mutex
+	headers  *test.BlockHeaders
+	latest   flow.BlockHeader
+	err      error
+	isSealed []bool
+}
+
+func newMockHeaderGetter() *mockHeaderGetter {
+	headers := test.BlockHeaderGenerator()
+	return &mockHeaderGetter{
+		headers: headers,
+		latest:  headers.New(),
+	}
+}
+
+func (m *mockHeaderGetter) GetLatestBlockHeader(_ context.Context, isSealed bool) (*flow.BlockHeader, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.isSealed = append(m.isSealed, isSealed)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	header := m.latest
+	return &header, nil
+}
+
+func (m *mockHeaderGetter) advance() flow.BlockHeader {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.latest = m.headers.New()
+	return m.latest
+}
+
+func TestProvider_Current(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Not ready before first refresh", func(t *testing.T) {
+		p := reference.NewProvider(newMockHeaderGetter())
+
+		_, err := p.Current()
+		assert.ErrorIs(t, err, reference.ErrNotReady)
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		client := newMockHeaderGetter()
+		p := reference.NewProvider(client, reference.WithSealed())
+
+		require.NoError(t, p.Refresh(ctx))
+
+		ref, err := p.Current()
+		require.NoError(t, err)
+		assert.Equal(t, client.latest.ID, ref.ID)
+		assert.Equal(t, client.latest.Height, ref.Height)
+		assert.Equal(t, client.latest.Height+flow.DefaultTransactionExpiry, ref.ExpiryHeight())
+		assert.Equal(t, []bool{true}, client.isSealed)
+
+		next := client.advance()
+		require.NoError(t, p.Refresh(ctx))
+
+		id, err := p.ReferenceBlockID()
+		require.NoError(t, err)
+		assert.Equal(t, next.ID, id)
+	})
+
+	t.Run("Ignores lower headers", func(t *testing.T) {
+		client := newMockHeaderGetter()
+		p := reference.NewProvider(client)
+
+		lower := client.latest
+		client.advance()
+		require.NoError(t, p.Refresh(ctx))
+
+		client.latest = lower
+		require.NoError(t, p.Refresh(ctx))
+
+		ref, err := p.Current()
+		require.NoError(t, err)
+		assert.Greater(t, ref.Height, lower.Height)
+	})
+
+	t.Run("Lower headers do not refresh", func(t *testing.T) {
+		client := newMockHeaderGetter()
+		p := reference.NewProvider(client, reference.WithMaxAge(5*time.Millisecond))
+
+		lower := client.latest
+		client.advance()
+		require.NoError(t, p.Refresh(ctx))
+
+		time.Sleep(10 * time.Millisecond)
+
+		client.latest = lower
+		require.NoError(t, p.Refresh(ctx))
+
+		_, err := p.Current()
+		assert.ErrorIs(t, err, reference.ErrStale)
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		client := newMockHeaderGetter()
+		p := reference.NewProvider(client, reference.WithMaxAge(time.Millisecond))
+
+		require.NoError(t, p.Refresh(ctx))
+
+		client.err = errors.New("unavailable")
+		assert.Error(t, p.Refresh(ctx))
+
+		time.Sleep(5 * time.Millisecond)
+
+		_, err := p.Current()
+		assert.ErrorIs(t, err, reference.ErrStale)
+		assert.Contains(t, err.Error(), "unavailable")
+	})
+}
+
+func TestProvider_Start(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newMockHeaderGetter()
+	p := reference.NewProvider(client, reference.WithRefreshInterval(time.Millisecond))
+
+	require.NoError(t, p.Start(ctx))
+
+	next := client.advance()
+	assert.Eventually(t, func() bool {
+		ref, err := p.Current()
+		return err == nil && ref.ID == next.ID
+	}, time.Second, time.Millisecond)
+
+	tx := flow.NewTransaction()
+	ref, err := p.SetReferenceBlock(tx)
+	require.NoError(t, err)
+	assert.Equal(t, ref.ID, tx.ReferenceBlockID)
+}
+
+func TestProvider_StartError(t *testing.T) {
+	client := newMockHeaderGetter()
+	client.err = errors.New("unavailable")
+
+	p := reference.NewProvider(client)
+	assert.Error(t, p.Start(context.Background()))
+}
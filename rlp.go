@@ -0,0 +1,398 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// This file implements the subset of RLP (Recursive Length Prefix) encoding used by the
+// canonical forms of Flow entities.
+//
+// The canonical forms have fixed shapes, so they are encoded without reflection: the size of
+// each value is computed up front, which allows an entity to be encoded into a single buffer
+// of the exact size. The encoding is byte-for-byte identical to the go-ethereum rlp package.
+
+const (
+	rlpStringOffset  = 0x80
+	rlpListOffset    = 0xc0
+	rlpMaxShortSize  = 55
+	rlpMaxSingleByte = 0x7f
+)
+
+var (
+	errRLPUnexpectedEnd    = errors.New("unexpected end of input")
+	errRLPTooFewElements   = errors.New("too few elements in list")
+	errRLPValueTooLarge    = errors.New("value size exceeds available input length")
+	errRLPElementTooLarge  = errors.New("element is larger than containing list")
+	errRLPExpectedString   = errors.New("expected string")
+	errRLPExpectedList     = errors.New("expected list")
+	errRLPNonCanonicalSize = errors.New("non-canonical size information")
+	errRLPNonCanonicalInt  = errors.New("non-canonical integer (leading zero bytes)")
+	errRLPUintOverflow     = errors.New("integer overflows uint64")
+	errRLPIntOverflow      = errors.New("integer overflows int")
+	errRLPTooManyElements  = errors.New("too many elements in list")
+	errRLPTrailingData     = errors.New("trailing data after value")
+	errRLPSignerOutOfRange = errors.New("signer index out of range")
+)
+
+// A DecodeError is returned when decoding the canonical RLP encoding of an entity fails.
+//
+// It reports the byte offset in the input at which decoding failed and the field that
+// was being decoded, e.g. "payload.arguments[1]".
+type DecodeError struct {
+	Offset int
+	Field  string
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("rlp: %s at offset %d", e.Err, e.Offset)
+	}
+
+	return fmt.Sprintf("rlp: %s at offset %d (%s)", e.Err, e.Offset, e.Field)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// withField annotates a decode error with the field being decoded. If the error
+// already names a nested field, the field is prepended to it.
+func withField(err error, field string) error {
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		if decodeErr.Field == "" {
+			decodeErr.Field = field
+		} else {
+			decodeErr.Field = field + "." + decodeErr.Field
+		}
+	}
+
+	return err
+}
+
+func rlpIntSize(u uint64) int {
+	return (bits.Len64(u) + 7) / 8
+}
+
+func rlpHeaderSize(size int) int {
+	if size <= rlpMaxShortSize {
+		return 1
+	}
+
+	return 1 + rlpIntSize(uint64(size))
+}
+
+func rlpBytesSize(b []byte) int {
+	if len(b) == 1 && b[0] <= rlpMaxSingleByte {
+		return 1
+	}
+
+	return rlpHeaderSize(len(b)) + len(b)
+}
+
+func rlpStringSize(s string) int {
+	if len(s) == 1 && s[0] <= rlpMaxSingleByte {
+		return 1
+	}
+
+	return rlpHeaderSize(len(s)) + len(s)
+}
+
+func rlpUintSize(u uint64) int {
+	if u <= rlpMaxSingleByte {
+		return 1
+	}
+
+	return 1 + rlpIntSize(u)
+}
+
+func rlpListSize(contentSize int) int {
+	return rlpHeaderSize(contentSize) + contentSize
+}
+
+func rlpAppendBigEndian(buf []byte, u uint64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		buf = append(buf, byte(u>>(8*i)))
+	}
+
+	return buf
+}
+
+func rlpAppendHeader(buf []byte, offset byte, size int) []byte {
+	if size <= rlpMaxShortSize {
+		return append(buf, offset+byte(size))
+	}
+
+	sizeSize := rlpIntSize(uint64(size))
+	buf = append(buf, offset+rlpMaxShortSize+byte(sizeSize))
+	return rlpAppendBigEndian(buf, uint64(size), sizeSize)
+}
+
+func rlpAppendBytes(buf []byte, b []byte) []byte {
+	if len(b) == 1 && b[0] <= rlpMaxSingleByte {
+		return append(buf, b[0])
+	}
+
+	buf = rlpAppendHeader(buf, rlpStringOffset, len(b))
+	return append(buf, b...)
+}
+
+func rlpAppendString(buf []byte, s string) []byte {
+	if len(s) == 1 && s[0] <= rlpMaxSingleByte {
+		return append(buf, s[0])
+	}
+
+	buf = rlpAppendHeader(buf, rlpStringOffset, len(s))
+	return append(buf, s...)
+}
+
+func rlpAppendUint(buf []byte, u uint64) []byte {
+	if u == 0 {
+		return append(buf, rlpStringOffset)
+	}
+	if u <= rlpMaxSingleByte {
+		return append(buf, byte(u))
+	}
+
+	size := rlpIntSize(u)
+	buf = append(buf, rlpStringOffset+byte(size))
+	return rlpAppendBigEndian(buf, u, size)
+}
+
+func rlpAppendListHeader(buf []byte, contentSize int) []byte {
+	return rlpAppendHeader(buf, rlpListOffset, contentSize)
+}
+
+// rlpDecoder decodes the values of an RLP list, or of a top-level input.
+//
+// Offsets are always relative to the start of the full input, so that errors
+// in nested values report their absolute position.
+type rlpDecoder struct {
+	input []byte
+	pos   int
+	end   int
+	list  bool
+}
+
+func newRLPDecoder(input []byte) *rlpDecoder {
+	return &rlpDecoder{
+		input: input,
+		end:   len(input),
+	}
+}
+
+func (d *rlpDecoder) error(offset int, err error) error {
+	return &DecodeError{
+		Offset: offset,
+		Err:    err,
+	}
+}
+
+// more returns true if there are values left to decode.
+func (d *rlpDecoder) more() bool {
+	return d.pos < d.end
+}
+
+// isList returns true if the next value is a list, without consuming it.
+func (d *rlpDecoder) isList() (bool, error) {
+	if !d.more() {
+		return false, d.endError()
+	}
+
+	return d.input[d.pos] >= rlpListOffset, nil
+}
+
+func (d *rlpDecoder) endError() error {
+	if d.list {
+		return d.error(d.pos, errRLPTooFewElements)
+	}
+
+	return d.error(d.pos, errRLPUnexpectedEnd)
+}
+
+// next consumes the next value and returns whether it is a list, as well as the bounds of its content.
+func (d *rlpDecoder) next() (list bool, start int, end int, err error) {
+	if !d.more() {
+		return false, 0, 0, d.endError()
+	}
+
+	offset := d.pos
+	prefix := d.input[offset]
+
+	var size uint64
+	switch {
+	case prefix <= rlpMaxSingleByte:
+		d.pos++
+		return false, offset, offset + 1, nil
+	case prefix < rlpStringOffset+rlpMaxShortSize+1:
+		start = offset + 1
+		size = uint64(prefix - rlpStringOffset)
+		if size == 1 && start < d.end && d.input[start] <= rlpMaxSingleByte {
+			return false, 0, 0, d.error(offset, errRLPNonCanonicalSize)
+		}
+	case prefix < rlpListOffset:
+		start, size, err = d.longSize(offset, int(prefix-rlpStringOffset-rlpMaxShortSize))
+	case prefix < rlpListOffset+rlpMaxShortSize+1:
+		list = true
+		start = offset + 1
+		size = uint64(prefix - rlpListOffset)
+	default:
+		list = true
+		start, size, err = d.longSize(offset, int(prefix-rlpListOffset-rlpMaxShortSize))
+	}
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	if size > uint64(d.end-start) {
+		if d.list {
+			return false, 0, 0, d.error(offset, errRLPElementTooLarge)
+		}
+		return false, 0, 0, d.error(offset, errRLPValueTooLarge)
+	}
+
+	end = start + int(size)
+	d.pos = end
+
+	return list, start, end, nil
+}
+
+// longSize decodes the size of a value with a long header.
+func (d *rlpDecoder) longSize(offset int, sizeSize int) (int, uint64, error) {
+	start := offset + 1 + sizeSize
+	if start > d.end {
+		return 0, 0, d.endError()
+	}
+
+	if d.input[offset+1] == 0 {
+		return 0, 0, d.error(offset, errRLPNonCanonicalSize)
+	}
+
+	var size uint64
+	for _, b := range d.input[offset+1 : start] {
+		size = size<<8 | uint64(b)
+	}
+
+	// sizes that fit a short header must use it
+	if size <= rlpMaxShortSize {
+		return 0, 0, d.error(offset, errRLPNonCanonicalSize)
+	}
+
+	return start, size, nil
+}
+
+// bytes decodes the next value as a byte string.
+//
+// The returned slice aliases the input.
+func (d *rlpDecoder) bytes() ([]byte, error) {
+	offset := d.pos
+	list, start, end, err := d.next()
+	if err != nil {
+		return nil, err
+	}
+	if list {
+		return nil, d.error(offset, errRLPExpectedString)
+	}
+
+	return d.input[start:end:end], nil
+}
+
+// copyBytes decodes the next value as a byte string and returns a copy of it.
+func (d *rlpDecoder) copyBytes() ([]byte, error) {
+	b, err := d.bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(make([]byte, 0, len(b)), b...), nil
+}
+
+func (d *rlpDecoder) uint() (uint64, error) {
+	offset := d.pos
+	b, err := d.bytes()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(b) > 8 {
+		return 0, d.error(offset, errRLPUintOverflow)
+	}
+	if len(b) > 0 && b[0] == 0 {
+		return 0, d.error(offset, errRLPNonCanonicalInt)
+	}
+
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+
+	return u, nil
+}
+
+func (d *rlpDecoder) int() (int, error) {
+	offset := d.pos
+	u, err := d.uint()
+	if err != nil {
+		return 0, err
+	}
+
+	if u > math.MaxInt {
+		return 0, d.error(offset, errRLPIntOverflow)
+	}
+
+	return int(u), nil
+}
+
+// listDecoder decodes the next value as a list and returns a decoder for its elements.
+func (d *rlpDecoder) listDecoder() (*rlpDecoder, error) {
+	offset := d.pos
+	list, start, end, err := d.next()
+	if err != nil {
+		return nil, err
+	}
+	if !list {
+		return nil, d.error(offset, errRLPExpectedList)
+	}
+
+	return &rlpDecoder{
+		input: d.input,
+		pos:   start,
+		end:   end,
+		list:  true,
+	}, nil
+}
+
+// finish returns an error if there are values left to decode.
+func (d *rlpDecoder) finish() error {
+	if !d.more() {
+		return nil
+	}
+
+	if d.list {
+		return d.error(d.pos, errRLPTooManyElements)
+	}
+
+	return d.error(d.pos, errRLPTrailingData)
+}
@@ -0,0 +1,387 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func goldenTransaction() *flow.Transaction {
+	tx := flow.NewTransaction().
+		SetScript(bytes.Repeat([]byte("a"), 60)).
+		SetReferenceBlockID(flow.HexToID("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b")).
+		SetGasLimit(9999).
+		SetProposalKey(flow.HexToAddress("01"), 4, 1<<40).
+		SetPayer(flow.HexToAddress("02")).
+		AddAuthorizer(flow.HexToAddress("01")).
+		AddAuthorizer(flow.HexToAddress("03")).
+		AddRawArgument([]byte(`{"value":"foo","type":"String"}`)).
+		AddRawArgument([]byte{0x01}).
+		AddRawArgument([]byte{0x80}).
+		AddRawArgument([]byte{})
+
+	tx.AddPayloadSignature(flow.HexToAddress("03"), 0, []byte{0x01})
+	tx.AddPayloadSignature(flow.HexToAddress("01"), 4, []byte("signature"))
+	tx.AddEnvelopeSignature(flow.HexToAddress("02"), 0, bytes.Repeat([]byte{0xab}, 64))
+
+	return tx
+}
+
+func TestTransaction_RLPGoldenVectors(t *testing.T) {
+	const (
+		goldenPayload  = "f8b4b83c616161616161616161616161616161616161616161616161616161616161616161616161616161616161616161616161616161616161616161616161e49f7b2276616c7565223a22666f6f222c2274797065223a22537472696e67227d01818080a0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b82270f8800000000000000010486010000000000880000000000000002d2880000000000000001880000000000000003"
+		goldenEnvelope = "f8c8" + goldenPayload + "d1cc8004897369676e6174757265c3028001"
+		goldenEncoded  = "f90110" + goldenPayload + "d1cc8004897369676e6174757265c3028001f846f8440180b840abababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababab"
+	)
+
+	t.Run("Empty", func(t *testing.T) {
+		tx := flow.NewTransaction()
+
+		const payload = "f83b80c0a0000000000000000000000000000000000000000000000000000000000000000082270f8800000000000000008080880000000000000000c0"
+
+		assert.Equal(t, payload, hex.EncodeToString(tx.PayloadMessage()))
+		assert.Equal(t, "f83e"+payload+"c0", hex.EncodeToString(tx.EnvelopeMessage()))
+		assert.Equal(t, "f83f"+payload+"c0c0", hex.EncodeToString(tx.Encode()))
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		tx := goldenTransaction()
+
+		assert.Equal(t, goldenPayload, hex.EncodeToString(tx.PayloadMessage()))
+		assert.Equal(t, goldenEnvelope, hex.EncodeToString(tx.EnvelopeMessage()))
+		assert.Equal(t, goldenEncoded, hex.EncodeToString(tx.Encode()))
+		assert.Equal(t, "e1bc32e318b680f2bc8625ff8a844aef9c2648fa82e5281a9a2ce3e305a77642", tx.ID().Hex())
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		expected := goldenTransaction()
+
+		for _, golden := range []string{goldenPayload, goldenEnvelope, goldenEncoded} {
+			b, err := hex.DecodeString(golden)
+			require.NoError(t, err)
+
+			tx, err := flow.DecodeTransaction(b)
+			require.NoError(t, err)
+			assert.Equal(t, expected.PayloadMessage(), tx.PayloadMessage())
+		}
+
+		b, err := hex.DecodeString(goldenEncoded)
+		require.NoError(t, err)
+
+		tx, err := flow.DecodeTransaction(b)
+		require.NoError(t, err)
+		assert.Equal(t, expected, tx)
+	})
+}
+
+func TestEntities_RLPGoldenVectors(t *testing.T) {
+	t.Run("Account key", func(t *testing.T) {
+		key := test.AccountKeyGenerator().New()
+
+		const encoded = "f847b840610c7cdbcc3b075b6181b94fef0ee18a49a56132824b1412415d86db95ffe0a40b75a7170de5422ab6ef2931a406c3e27a8a69dd098ad9ad0bb13b748840cbe602038203e8"
+		assert.Equal(t, encoded, hex.EncodeToString(key.Encode()))
+
+		b, err := hex.DecodeString(encoded)
+		require.NoError(t, err)
+
+		decoded, err := flow.DecodeAccountKey(b)
+		require.NoError(t, err)
+		assert.Equal(t, key.PublicKey.Encode(), decoded.PublicKey.Encode())
+		assert.Equal(t, key.SigAlgo, decoded.SigAlgo)
+		assert.Equal(t, key.HashAlgo, decoded.HashAlgo)
+		assert.Equal(t, key.Weight, decoded.Weight)
+	})
+
+	t.Run("Event", func(t *testing.T) {
+		event := flow.Event{
+			Type:             "A.0000000000000001.Foo.Bar",
+			TransactionID:    flow.HexToID("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b"),
+			TransactionIndex: 2,
+			EventIndex:       200,
+			Payload:          []byte(`{"type":"Event"}`),
+		}
+
+		assert.Equal(t, "e3a0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b81c8", hex.EncodeToString(event.Encode()))
+		assert.Equal(t, "0x9fcc7225b8b285cc186138bb7a6ace80ff99ea7457c2a4303a7345efebe7432c", event.ID())
+		assert.Equal(t,
+			"f850a0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b81c89a412e303030303030303030303030303030312e466f6f2e42617202907b2274797065223a224576656e74227d",
+			hex.EncodeToString(event.Fingerprint()),
+		)
+	})
+
+	t.Run("Collection", func(t *testing.T) {
+		collection := flow.Collection{
+			TransactionIDs: []flow.Identifier{
+				flow.HexToID("01"),
+				flow.HexToID("e1bc32e318b680f2bc8625ff8a844aef9c2648fa82e5281a9a2ce3e305a77642"),
+			},
+		}
+
+		assert.Equal(t,
+			"f844f842a00100000000000000000000000000000000000000000000000000000000000000a0e1bc32e318b680f2bc8625ff8a844aef9c2648fa82e5281a9a2ce3e305a77642",
+			hex.EncodeToString(collection.Encode()),
+		)
+	})
+}
+
+// reflectionPayload mirrors the canonical payload form as encoded by the go-ethereum rlp package.
+type reflectionPayload struct {
+	Script                    []byte
+	Arguments                 [][]byte
+	ReferenceBlockID          []byte
+	GasLimit                  uint64
+	ProposalKeyAddress        []byte
+	ProposalKeyIndex          uint64
+	ProposalKeySequenceNumber uint64
+	Payer                     []byte
+	Authorizers               [][]byte
+}
+
+type reflectionSignature struct {
+	SignerIndex uint
+	KeyIndex    uint
+	Signature   []byte
+}
+
+func reflectionEncode(t *testing.T, tx *flow.Transaction) (payload, envelope, encoded []byte) {
+	authorizers := make([][]byte, len(tx.Authorizers))
+	for i, auth := range tx.Authorizers {
+		authorizers[i] = auth.Bytes()
+	}
+
+	p := reflectionPayload{
+		Script:                    tx.Script,
+		Arguments:                 tx.Arguments,
+		ReferenceBlockID:          tx.ReferenceBlockID.Bytes(),
+		GasLimit:                  tx.GasLimit,
+		ProposalKeyAddress:        tx.ProposalKey.Address.Bytes(),
+		ProposalKeyIndex:          uint64(tx.ProposalKey.KeyIndex),
+		ProposalKeySequenceNumber: tx.ProposalKey.SequenceNumber,
+		Payer:                     tx.Payer.Bytes(),
+		Authorizers:               authorizers,
+	}
+
+	signatures := func(sigs []flow.TransactionSignature) []reflectionSignature {
+		result := make([]reflectionSignature, len(sigs))
+		for i, sig := range sigs {
+			result[i] = reflectionSignature{
+				SignerIndex: uint(sig.SignerIndex),
+				KeyIndex:    uint(sig.KeyIndex),
+				Signature:   sig.Signature,
+			}
+		}
+		return result
+	}
+
+	var err error
+
+	payload, err = rlp.EncodeToBytes(&p)
+	require.NoError(t, err)
+
+	envelope, err = rlp.EncodeToBytes([]interface{}{p, signatures(tx.PayloadSignatures)})
+	require.NoError(t, err)
+
+	encoded, err = rlp.EncodeToBytes([]interface{}{p, signatures(tx.PayloadSignatures), signatures(tx.EnvelopeSignatures)})
+	require.NoError(t, err)
+
+	return payload, envelope, encoded
+}
+
+func randomBytes(r *rand.Rand, max int) []byte {
+	b := make([]byte, r.Intn(max+1))
+	r.Read(b)
+	return b
+}
+
+func randomTransaction(r *rand.Rand) *flow.Transaction {
+	addresses := test.AddressGenerator()
+	accounts := []flow.Address{addresses.New(), addresses.New(), addresses.New()}
+
+	var refBlockID flow.Identifier
+	r.Read(refBlockID[:])
+
+	// biased towards small values to exercise single byte encodings
+	randomUint := func() uint64 {
+		return r.Uint64() >> r.Intn(64)
+	}
+
+	tx := flow.NewTransaction().
+		SetScript(randomBytes(r, 2000)).
+		SetReferenceBlockID(refBlockID).
+		SetGasLimit(randomUint()).
+		SetProposalKey(accounts[r.Intn(3)], int(randomUint()>>1), randomUint()).
+		SetPayer(accounts[r.Intn(3)])
+
+	for i := r.Intn(4); i > 0; i-- {
+		tx.AddAuthorizer(accounts[r.Intn(3)])
+	}
+
+	for i := r.Intn(5); i > 0; i-- {
+		tx.Arguments = append(tx.Arguments, randomBytes(r, 300))
+	}
+
+	for i := r.Intn(4); i > 0; i-- {
+		tx.AddPayloadSignature(tx.ProposalKey.Address, r.Intn(300), randomBytes(r, 100))
+	}
+
+	for i := r.Intn(3); i > 0; i-- {
+		tx.AddEnvelopeSignature(tx.Payer, r.Intn(300), randomBytes(r, 100))
+	}
+
+	return tx
+}
+
+func TestTransaction_RLPCompatibility(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 500; i++ {
+		tx := randomTransaction(r)
+
+		payload, envelope, encoded := reflectionEncode(t, tx)
+		require.Equal(t, payload, tx.PayloadMessage())
+		require.Equal(t, envelope, tx.EnvelopeMessage())
+		require.Equal(t, encoded, tx.Encode())
+
+		for _, b := range [][]byte{payload, envelope, encoded} {
+			decoded, err := flow.DecodeTransaction(b)
+			require.NoError(t, err)
+			require.Equal(t, tx.PayloadMessage(), decoded.PayloadMessage())
+		}
+
+		decoded, err := flow.DecodeTransaction(encoded)
+		require.NoError(t, err)
+		require.Equal(t, encoded, decoded.Encode())
+	}
+}
+
+func TestDecodeTransaction_Errors(t *testing.T) {
+	encoded := goldenTransaction().Encode()
+
+	decodeError := func(t *testing.T, b []byte) *flow.DecodeError {
+		_, err := flow.DecodeTransaction(b)
+		require.Error(t, err)
+
+		var decodeErr *flow.DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		return decodeErr
+	}
+
+	t.Run("Empty input", func(t *testing.T) {
+		err := decodeError(t, nil)
+		assert.Equal(t, 0, err.Offset)
+		assert.Equal(t, "transaction", err.Field)
+	})
+
+	t.Run("Truncated input", func(t *testing.T) {
+		err := decodeError(t, encoded[:len(encoded)-1])
+		assert.Equal(t, 0, err.Offset)
+		assert.Equal(t, "transaction", err.Field)
+	})
+
+	t.Run("Trailing data", func(t *testing.T) {
+		err := decodeError(t, append(append([]byte{}, encoded...), 0x80))
+		assert.Equal(t, len(encoded), err.Offset)
+		assert.EqualError(t, err, "rlp: trailing data after value at offset 275")
+	})
+
+	t.Run("Non-canonical integer", func(t *testing.T) {
+		// encode the gas limit 9999 (82270f) with a leading zero byte
+		b, err := hex.DecodeString("f83c80c0a0000000000000000000000000000000000000000000000000000000000000000083" + "00270f" + "8800000000000000008080880000000000000000c0")
+		require.NoError(t, err)
+
+		decodeErr := decodeError(t, b)
+		assert.Equal(t, 37, decodeErr.Offset)
+		assert.Equal(t, "payload.gasLimit", decodeErr.Field)
+	})
+
+	t.Run("Argument is a list", func(t *testing.T) {
+		b, err := hex.DecodeString("f83c80c1c0a0000000000000000000000000000000000000000000000000000000000000000082270f8800000000000000008080880000000000000000c0")
+		require.NoError(t, err)
+
+		decodeErr := decodeError(t, b)
+		assert.Equal(t, 4, decodeErr.Offset)
+		assert.Equal(t, "payload.arguments[0]", decodeErr.Field)
+	})
+
+	t.Run("Unknown signer", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(flow.HexToAddress("01"), 0, 0).
+			SetPayer(flow.HexToAddress("01"))
+		tx.PayloadSignatures = []flow.TransactionSignature{{SignerIndex: 1, Signature: []byte{1}}}
+
+		decodeErr := decodeError(t, tx.Encode())
+		assert.Equal(t, "payloadSignatures[0].signerIndex", decodeErr.Field)
+	})
+
+	t.Run("Too many payload fields", func(t *testing.T) {
+		b, err := hex.DecodeString("f83c80c0a0000000000000000000000000000000000000000000000000000000000000000082270f8800000000000000008080880000000000000000c080")
+		require.NoError(t, err)
+
+		decodeErr := decodeError(t, b)
+		assert.Equal(t, 61, decodeErr.Offset)
+		assert.Equal(t, "payload", decodeErr.Field)
+	})
+}
+
+func BenchmarkTransaction_Encode(b *testing.B) {
+	tx := test.TransactionGenerator().New()
+
+	b.Run("PayloadMessage", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = tx.PayloadMessage()
+		}
+	})
+
+	b.Run("EnvelopeMessage", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = tx.EnvelopeMessage()
+		}
+	})
+
+	b.Run("Encode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = tx.Encode()
+		}
+	})
+}
+
+func BenchmarkDecodeTransaction(b *testing.B) {
+	encoded := test.TransactionGenerator().New().Encode()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := flow.DecodeTransaction(encoded)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sender
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+const errorMessagePrefix = "sender: "
+
+// A BuildError indicates that a transaction could not be built or signed.
+type BuildError struct {
+	Message string
+	Err     error
+}
+
+func newBuildError(message string, err error) *BuildError {
+	return &BuildError{
+		Message: message,
+		Err:     err,
+	}
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("%s%s: %s", errorMessagePrefix, e.Message, e.Err.Error())
+}
+
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// A SubmitError indicates that the access node rejected a transaction.
+type SubmitError struct {
+	TransactionID flow.Identifier
+	Err           error
+}
+
+func (e *SubmitError) Error() string {
+	return fmt.Sprintf("%sfailed to submit transaction %s: %s", errorMessagePrefix, e.TransactionID, e.Err.Error())
+}
+
+func (e *SubmitError) Unwrap() error {
+	return e.Err
+}
+
+// An ExecutionError indicates that a transaction was sealed with an execution error.
+//
+// The sealed result, including any emitted events, is available in Result.
+type ExecutionError struct {
+	TransactionID flow.Identifier
+	Result        *flow.TransactionResult
+}
+
+func (e *ExecutionError) Error() string {
+	return fmt.Sprintf("%stransaction %s failed: %s", errorMessagePrefix, e.TransactionID, e.Result.Error.Error())
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Result.Error
+}
+
+// An ExpiredError indicates that a transaction expired before it was included in a block.
+type ExpiredError struct {
+	TransactionID flow.Identifier
+}
+
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("%stransaction %s expired", errorMessagePrefix, e.TransactionID)
+}
+
+// A ResultError indicates that the result of a submitted transaction could not be retrieved.
+//
+// Err is either the non-retryable error returned by the access node, or the context error if
+// the context was done before the transaction was sealed. In the latter case, LastErr holds the
+// last error returned by the access node while polling.
+type ResultError struct {
+	TransactionID flow.Identifier
+	Err           error
+	LastErr       error
+}
+
+func (e *ResultError) Error() string {
+	message := fmt.Sprintf("%sfailed to get result of transaction %s: %s", errorMessagePrefix, e.TransactionID, e.Err.Error())
+	if e.LastErr != nil {
+		message += fmt.Sprintf(" (last error: %s)", e.LastErr.Error())
+	}
+
+	return message
+}
+
+func (e *ResultError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,418 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sender provides an end-to-end pipeline for building, signing, submitting
+// and awaiting Flow transactions.
+//
+// A Sender takes care of the individual steps required to send a transaction: it
+// fetches a reference block and the proposal key sequence number, assigns the signer
+// roles, signs the payload and envelope in the correct order, submits the transaction
+// and polls for its result until it is sealed.
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	flowhttp "github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// DefaultPollInterval is the default interval between transaction result requests.
+const DefaultPollInterval = time.Second
+
+// Client is the subset of the access API used by the Sender.
+type Client interface {
+	GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error)
+	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
+	SendTransaction(ctx context.Context, tx flow.Transaction) error
+	GetTransactionResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error)
+}
+
+// A Role binds an account key to the signer able to sign for it.
+type Role struct {
+	Address  flow.Address
+	KeyIndex int
+	Signer   crypto.Signer
+}
+
+// A Request describes a transaction to be sent.
+type Request struct {
+	// Script is the Cadence source code of the transaction.
+	Script []byte
+	// Arguments are the Cadence values passed to the transaction.
+	Arguments []cadence.Value
+	// GasLimit is the computation limit of the transaction.
+	//
+	// If zero, flow.DefaultTransactionGasLimit is used.
+	GasLimit uint64
+	// Proposer is the key proposing the transaction.
+	Proposer Role
+	// Payer is the key paying for the transaction.
+	Payer Role
+	// Authorizers are the keys authorizing the transaction, in declaration order.
+	Authorizers []Role
+}
+
+// A ReferenceBlockProvider provides the reference block ID for new transactions.
+//
+// The reference.Provider type satisfies this interface.
+type ReferenceBlockProvider interface {
+	ReferenceBlockID() (flow.Identifier, error)
+}
+
+// A Sender builds, signs, submits and awaits transactions.
+//
+// A Sender is safe for concurrent use.
+type Sender struct {
+	client       Client
+	references   ReferenceBlockProvider
+	pollInterval time.Duration
+}
+
+// An Option configures a Sender.
+type Option func(*Sender)
+
+// WithPollInterval sets the interval between transaction result requests.
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *Sender) {
+		s.pollInterval = interval
+	}
+}
+
+// WithReferenceBlockProvider makes the sender take reference blocks from the given provider
+// instead of fetching the latest block header for each transaction.
+func WithReferenceBlockProvider(provider ReferenceBlockProvider) Option {
+	return func(s *Sender) {
+		s.references = provider
+	}
+}
+
+// New creates a new Sender using the provided access client.
+func New(client Client, opts ...Option) *Sender {
+	s := &Sender{
+		client:       client,
+		pollInterval: DefaultPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Send builds, signs and submits the requested transaction and waits until it is sealed.
+//
+// The returned error is a *BuildError, *SubmitError, *ExecutionError or *ExpiredError
+// depending on the stage at which sending failed, or the context error if the context
+// is cancelled while waiting. An ExecutionError is returned together with the sealed result.
+func (s *Sender) Send(ctx context.Context, req Request) (*flow.TransactionResult, error) {
+	tx, err := s.Build(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Submit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Wait(ctx, tx.ID())
+}
+
+// Build builds and signs the requested transaction without submitting it.
+func (s *Sender) Build(ctx context.Context, req Request) (*flow.Transaction, error) {
+	err := validateRoles(req)
+	if err != nil {
+		return nil, err
+	}
+
+	referenceBlockID, err := s.referenceBlockID(ctx)
+	if err != nil {
+		return nil, newBuildError("failed to fetch reference block", err)
+	}
+
+	account, err := s.client.GetAccountAtLatestBlock(ctx, req.Proposer.Address)
+	if err != nil {
+		return nil, newBuildError("failed to fetch proposer account", err)
+	}
+
+	sequenceNumber, err := proposalSequenceNumber(account, req.Proposer.KeyIndex)
+	if err != nil {
+		return nil, newBuildError("invalid proposal key", err)
+	}
+
+	gasLimit := req.GasLimit
+	if gasLimit == 0 {
+		gasLimit = flow.DefaultTransactionGasLimit
+	}
+
+	tx := flow.NewTransaction().
+		SetScript(req.Script).
+		SetReferenceBlockID(referenceBlockID).
+		SetGasLimit(gasLimit).
+		SetProposalKey(req.Proposer.Address, req.Proposer.KeyIndex, sequenceNumber).
+		SetPayer(req.Payer.Address)
+
+	for _, authorizer := range req.Authorizers {
+		tx.AddAuthorizer(authorizer.Address)
+	}
+
+	for _, arg := range req.Arguments {
+		err = tx.AddArgument(arg)
+		if err != nil {
+			return nil, newBuildError("invalid argument", err)
+		}
+	}
+
+	err = Sign(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (s *Sender) referenceBlockID(ctx context.Context) (flow.Identifier, error) {
+	if s.references != nil {
+		return s.references.ReferenceBlockID()
+	}
+
+	header, err := s.client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	return header.ID, nil
+}
+
+// Sign signs the transaction with the keys bound to the request roles.
+//
+// Keys of accounts other than the payer sign the payload first; the payer key, and any
+// other key of the payer account, then sign the envelope. Each key signs at most once,
+// even if it is bound to several roles. The context is passed to signers that implement
+// crypto.ContextSigner.
+func Sign(ctx context.Context, tx *flow.Transaction, req Request) error {
+	err := validateRoles(req)
+	if err != nil {
+		return err
+	}
+
+	payloadRoles, envelopeRoles := signingRoles(req)
+
+	for _, role := range payloadRoles {
+		err := tx.SignPayloadWithContext(ctx, role.Address, role.KeyIndex, role.Signer)
+		if err != nil {
+			return newBuildError(fmt.Sprintf("failed to sign payload with key %d of %s", role.KeyIndex, role.Address), err)
+		}
+	}
+
+	for _, role := range envelopeRoles {
+		err := tx.SignEnvelopeWithContext(ctx, role.Address, role.KeyIndex, role.Signer)
+		if err != nil {
+			return newBuildError(fmt.Sprintf("failed to sign envelope with key %d of %s", role.KeyIndex, role.Address), err)
+		}
+	}
+
+	return nil
+}
+
+// validateRoles checks that every role of the request has a signer.
+func validateRoles(req Request) error {
+	check := func(name string, role Role) error {
+		if role.Signer == nil {
+			return newBuildError(
+				fmt.Sprintf("invalid %s role", name),
+				fmt.Errorf("key %d of %s has no signer", role.KeyIndex, role.Address),
+			)
+		}
+		return nil
+	}
+
+	err := check("proposer", req.Proposer)
+	if err != nil {
+		return err
+	}
+
+	err = check("payer", req.Payer)
+	if err != nil {
+		return err
+	}
+
+	for _, authorizer := range req.Authorizers {
+		err = check("authorizer", authorizer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type roleKey struct {
+	address  flow.Address
+	keyIndex int
+}
+
+func signingRoles(req Request) (payload []Role, envelope []Role) {
+	seen := make(map[roleKey]struct{})
+
+	add := func(role Role) {
+		key := roleKey{address: role.Address, keyIndex: role.KeyIndex}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+
+		if role.Address == req.Payer.Address {
+			envelope = append(envelope, role)
+		} else {
+			payload = append(payload, role)
+		}
+	}
+
+	// the payer is added first so that its key is never duplicated by another role
+	add(req.Payer)
+	add(req.Proposer)
+	for _, authorizer := range req.Authorizers {
+		add(authorizer)
+	}
+
+	return payload, envelope
+}
+
+func proposalSequenceNumber(account *flow.Account, keyIndex int) (uint64, error) {
+	for _, key := range account.Keys {
+		if key.Index != keyIndex {
+			continue
+		}
+
+		if key.Revoked {
+			return 0, fmt.Errorf("key %d on account %s is revoked", keyIndex, account.Address)
+		}
+
+		return key.SequenceNumber, nil
+	}
+
+	return 0, fmt.Errorf("key %d not found on account %s", keyIndex, account.Address)
+}
+
+// Submit submits a signed transaction to the network.
+func (s *Sender) Submit(ctx context.Context, tx *flow.Transaction) error {
+	err := s.client.SendTransaction(ctx, *tx)
+	if err != nil {
+		return &SubmitError{
+			TransactionID: tx.ID(),
+			Err:           err,
+		}
+	}
+
+	return nil
+}
+
+// Wait polls for the result of a submitted transaction until it is sealed or expired.
+//
+// Errors returned by the access node are retried until the context is done, except for
+// errors that cannot be resolved by retrying, such as an unknown transaction, which are
+// returned immediately as a ResultError. If the context is done while the last request
+// failed, the context error is returned as a ResultError that also holds the last error.
+func (s *Sender) Wait(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+
+	for {
+		result, err := s.client.GetTransactionResult(ctx, txID)
+		if err == nil && result == nil {
+			err = errors.New("access node returned no result")
+		}
+
+		if err != nil {
+			if !isRetryable(err) {
+				return nil, &ResultError{
+					TransactionID: txID,
+					Err:           err,
+				}
+			}
+			lastErr = err
+		} else {
+			lastErr = nil
+
+			switch result.Status {
+			case flow.TransactionStatusSealed:
+				if result.Error != nil {
+					return result, &ExecutionError{
+						TransactionID: txID,
+						Result:        result,
+					}
+				}
+				return result, nil
+			case flow.TransactionStatusExpired:
+				return result, &ExpiredError{TransactionID: txID}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, &ResultError{
+					TransactionID: txID,
+					Err:           ctx.Err(),
+					LastErr:       lastErr,
+				}
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// isRetryable reports whether a request that failed with err may succeed when retried.
+func isRetryable(err error) bool {
+	var rpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.GRPCStatus().Code() {
+		case codes.NotFound,
+			codes.InvalidArgument,
+			codes.PermissionDenied,
+			codes.Unauthenticated,
+			codes.Unimplemented:
+			return false
+		}
+		return true
+	}
+
+	var httpErr flowhttp.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code >= http.StatusInternalServerError ||
+			httpErr.Code == http.StatusTooManyRequests ||
+			httpErr.Code == http.StatusRequestTimeout
+	}
+
+	return true
+}
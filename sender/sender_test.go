@@ -0,0 +1,400 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/reference"
+	"github.com/onflow/flow-go-sdk/sender"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	_ sender.Client = (*grpc.Client)(nil)
+	_ sender.Client = (*http.Client)(nil)
+)
+
+type mockClient struct {
+	mu       sync.Mutex
+	header   *flow.BlockHeader
+	accounts map[flow.Address]*flow.Account
+	sent     []flow.Transaction
+	sendErr  error
+	results  []*flow.TransactionResult
+	// resultErr is returned by GetTransactionResult while set
+	resultErr error
+}
+
+func (c *mockClient) GetLatestBlockHeader(_ context.Context, _ bool) (*flow.BlockHeader, error) {
+	return c.header, nil
+}
+
+func (c *mockClient) GetAccountAtLatestBlock(_ context.Context, address flow.Address) (*flow.Account, error) {
+	account, ok := c.accounts[address]
+	if !ok {
+		return nil, errors.New("account not found")
+	}
+	return account, nil
+}
+
+func (c *mockClient) SendTransaction(_ context.Context, tx flow.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sendErr != nil {
+		return c.sendErr
+	}
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func (c *mockClient) GetTransactionResult(_ context.Context, _ flow.Identifier) (*flow.TransactionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.resultErr != nil {
+		return nil, c.resultErr
+	}
+
+	result := c.results[0]
+	if len(c.results) > 1 {
+		c.results = c.results[1:]
+	}
+	return result, nil
+}
+
+type fixture struct {
+	client    *mockClient
+	proposer  sender.Role
+	payer     sender.Role
+	authorize sender.Role
+}
+
+func newFixture() *fixture {
+	accounts := test.AccountGenerator()
+	keys := test.AccountKeyGenerator()
+
+	newRole := func() (sender.Role, *flow.Account) {
+		account := accounts.New()
+		key, signer := keys.NewWithSigner()
+		key.Index = 0
+		account.Keys = []*flow.AccountKey{key}
+		return sender.Role{Address: account.Address, KeyIndex: 0, Signer: signer}, account
+	}
+
+	proposer, proposerAccount := newRole()
+	payer, payerAccount := newRole()
+	authorizer, authorizerAccount := newRole()
+
+	header := test.BlockHeaderGenerator().New()
+
+	return &fixture{
+		client: &mockClient{
+			header: &header,
+			accounts: map[flow.Address]*flow.Account{
+				proposerAccount.Address:   proposerAccount,
+				payerAccount.Address:      payerAccount,
+				authorizerAccount.Address: authorizerAccount,
+			},
+			results: []*flow.TransactionResult{{Status: flow.TransactionStatusSealed}},
+		},
+		proposer:  proposer,
+		payer:     payer,
+		authorize: authorizer,
+	}
+}
+
+func TestSender_Build(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Separate roles", func(t *testing.T) {
+		f := newFixture()
+		s := sender.New(f.client)
+
+		tx, err := s.Build(ctx, sender.Request{
+			Script:      test.GreetingScript,
+			Arguments:   []cadence.Value{cadence.String("hello")},
+			Proposer:    f.proposer,
+			Payer:       f.payer,
+			Authorizers: []sender.Role{f.authorize},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, f.client.header.ID, tx.ReferenceBlockID)
+		assert.Equal(t, uint64(flow.DefaultTransactionGasLimit), tx.GasLimit)
+		assert.Equal(t, uint64(42), tx.ProposalKey.SequenceNumber)
+		assert.Equal(t, f.payer.Address, tx.Payer)
+		assert.Equal(t, []flow.Address{f.authorize.Address}, tx.Authorizers)
+		require.Len(t, tx.Arguments, 1)
+
+		require.Len(t, tx.PayloadSignatures, 2)
+		assert.Equal(t, f.proposer.Address, tx.PayloadSignatures[0].Address)
+		assert.Equal(t, f.authorize.Address, tx.PayloadSignatures[1].Address)
+
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, f.payer.Address, tx.EnvelopeSignatures[0].Address)
+
+		assertValidSignature(t, f.proposer.Signer.PublicKey(), tx.PayloadMessage(), tx.PayloadSignatures[0].Signature)
+		assertValidSignature(t, f.payer.Signer.PublicKey(), tx.EnvelopeMessage(), tx.EnvelopeSignatures[0].Signature)
+	})
+
+	t.Run("Single party", func(t *testing.T) {
+		f := newFixture()
+		s := sender.New(f.client)
+
+		tx, err := s.Build(ctx, sender.Request{
+			Script:      test.GreetingScript,
+			GasLimit:    100,
+			Proposer:    f.payer,
+			Payer:       f.payer,
+			Authorizers: []sender.Role{f.payer},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(100), tx.GasLimit)
+		assert.Empty(t, tx.PayloadSignatures)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, f.payer.Address, tx.EnvelopeSignatures[0].Address)
+	})
+
+	t.Run("Reference block provider", func(t *testing.T) {
+		f := newFixture()
+		provider := reference.NewProvider(f.client)
+		require.NoError(t, provider.Refresh(ctx))
+
+		f.client.header = &flow.BlockHeader{ID: flow.HexToID("01")}
+		s := sender.New(f.client, sender.WithReferenceBlockProvider(provider))
+
+		tx, err := s.Build(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+		require.NoError(t, err)
+
+		ref, err := provider.Current()
+		require.NoError(t, err)
+		assert.Equal(t, ref.ID, tx.ReferenceBlockID)
+	})
+
+	t.Run("Unknown proposal key", func(t *testing.T) {
+		f := newFixture()
+		s := sender.New(f.client)
+
+		proposer := f.proposer
+		proposer.KeyIndex = 3
+
+		_, err := s.Build(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: proposer,
+			Payer:    f.payer,
+		})
+
+		var buildErr *sender.BuildError
+		assert.ErrorAs(t, err, &buildErr)
+	})
+
+	t.Run("Missing signer", func(t *testing.T) {
+		f := newFixture()
+		s := sender.New(f.client)
+
+		authorizer := f.authorize
+		authorizer.Signer = nil
+
+		_, err := s.Build(ctx, sender.Request{
+			Script:      test.GreetingScript,
+			Proposer:    f.payer,
+			Payer:       f.payer,
+			Authorizers: []sender.Role{authorizer},
+		})
+
+		var buildErr *sender.BuildError
+		assert.ErrorAs(t, err, &buildErr)
+	})
+
+	t.Run("Cancelled signing", func(t *testing.T) {
+		f := newFixture()
+		s := sender.New(f.client)
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := s.Build(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+
+		var buildErr *sender.BuildError
+		require.ErrorAs(t, err, &buildErr)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func assertValidSignature(t *testing.T, publicKey crypto.PublicKey, message []byte, sig []byte) {
+	hasher, err := crypto.NewHasher(crypto.SHA3_256)
+	require.NoError(t, err)
+
+	message = append(flow.TransactionDomainTag[:], message...)
+	valid, err := publicKey.Verify(sig, message, hasher)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestSender_Send(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sealed", func(t *testing.T) {
+		f := newFixture()
+		f.client.results = []*flow.TransactionResult{
+			{Status: flow.TransactionStatusPending},
+			{Status: flow.TransactionStatusExecuted},
+			{Status: flow.TransactionStatusSealed},
+		}
+		s := sender.New(f.client, sender.WithPollInterval(time.Millisecond))
+
+		result, err := s.Send(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+		assert.Len(t, f.client.sent, 1)
+	})
+
+	t.Run("Execution failure", func(t *testing.T) {
+		f := newFixture()
+		f.client.results = []*flow.TransactionResult{
+			{Status: flow.TransactionStatusSealed, Error: errors.New("[Error Code: 1101] panic")},
+		}
+		s := sender.New(f.client, sender.WithPollInterval(time.Millisecond))
+
+		result, err := s.Send(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+
+		var execErr *sender.ExecutionError
+		require.ErrorAs(t, err, &execErr)
+		assert.Equal(t, result, execErr.Result)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		f := newFixture()
+		f.client.results = []*flow.TransactionResult{{Status: flow.TransactionStatusExpired}}
+		s := sender.New(f.client, sender.WithPollInterval(time.Millisecond))
+
+		_, err := s.Send(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+
+		var expiredErr *sender.ExpiredError
+		assert.ErrorAs(t, err, &expiredErr)
+	})
+
+	t.Run("Submit failure", func(t *testing.T) {
+		f := newFixture()
+		f.client.sendErr = errors.New("rejected")
+		s := sender.New(f.client)
+
+		_, err := s.Send(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+
+		var submitErr *sender.SubmitError
+		require.ErrorAs(t, err, &submitErr)
+		assert.ErrorIs(t, err, f.client.sendErr)
+	})
+
+	t.Run("Context cancelled", func(t *testing.T) {
+		f := newFixture()
+		f.client.results = []*flow.TransactionResult{{Status: flow.TransactionStatusPending}}
+		s := sender.New(f.client, sender.WithPollInterval(time.Millisecond))
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := s.Send(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Result not found", func(t *testing.T) {
+		f := newFixture()
+		f.client.resultErr = status.Error(codes.NotFound, "transaction not found")
+		s := sender.New(f.client, sender.WithPollInterval(time.Millisecond))
+
+		_, err := s.Send(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+
+		var resultErr *sender.ResultError
+		require.ErrorAs(t, err, &resultErr)
+		assert.ErrorIs(t, err, f.client.resultErr)
+		assert.Nil(t, resultErr.LastErr)
+	})
+
+	t.Run("Result unavailable until context is done", func(t *testing.T) {
+		f := newFixture()
+		f.client.resultErr = status.Error(codes.Unavailable, "connection refused")
+		s := sender.New(f.client, sender.WithPollInterval(time.Millisecond))
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := s.Send(ctx, sender.Request{
+			Script:   test.GreetingScript,
+			Proposer: f.payer,
+			Payer:    f.payer,
+		})
+
+		var resultErr *sender.ResultError
+		require.ErrorAs(t, err, &resultErr)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, f.client.resultErr, resultErr.LastErr)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+}
@@ -19,6 +19,7 @@
 package flow
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -62,6 +63,78 @@ func padDomainTag(tag string) (paddedTag [domainTagLength]byte, err error) {
 // User messages are distinct from other signed messages (i.e. transactions), and can be
 // verified directly in on-chain Cadence code.
 func SignUserMessage(signer crypto.Signer, message []byte) ([]byte, error) {
+	return SignUserMessageWithContext(context.Background(), signer, message)
+}
+
+// SignUserMessageWithContext signs a message in the user domain like SignUserMessage,
+// passing the context to the signer if it is a crypto.ContextSigner.
+func SignUserMessageWithContext(ctx context.Context, signer crypto.Signer, message []byte) ([]byte, error) {
+	message = append(UserDomainTag[:], message...)
+	return crypto.SignWithContext(ctx, signer, message)
+}
+
+// VerifyUserSignature verifies user message signatures, as produced by SignUserMessage, against
+// the keys of an account.
+//
+// Each signature is verified against the account key at the same position in keyIndices. All
+// signatures must be valid, none of the keys may be revoked, and the total weight of the keys
+// must reach AccountKeyWeightThreshold. Each key may only sign once.
+//
+// This is the equivalent of verifying the signatures with a Crypto.KeyList in Cadence.
+func VerifyUserSignature(account *Account, message []byte, sigs [][]byte, keyIndices []int) error {
+	return verifyUserSignature(account, message, sigs, keyIndices, false)
+}
+
+// VerifyUserSignatureAnyKey verifies user message signatures, as produced by SignUserMessage,
+// against the keys of an account.
+//
+// Unlike VerifyUserSignature, invalid signatures and signatures of revoked or unknown keys are
+// ignored. The signatures are accepted if the total weight of the keys with a valid signature
+// reaches AccountKeyWeightThreshold, such as when any full-weight key has signed the message.
+func VerifyUserSignatureAnyKey(account *Account, message []byte, sigs [][]byte, keyIndices []int) error {
+	return verifyUserSignature(account, message, sigs, keyIndices, true)
+}
+
+func verifyUserSignature(account *Account, message []byte, sigs [][]byte, keyIndices []int, anyKey bool) error {
+	if len(sigs) != len(keyIndices) {
+		return fmt.Errorf("got %d signatures for %d key indices", len(sigs), len(keyIndices))
+	}
+
 	message = append(UserDomainTag[:], message...)
-	return signer.Sign(message)
+
+	weight := 0
+	signed := make(map[int]bool, len(keyIndices))
+
+	for i, keyIndex := range keyIndices {
+		if signed[keyIndex] {
+			if anyKey {
+				continue
+			}
+			return fmt.Errorf("%w: key %d of %s signed more than once", ErrInvalidSignature, keyIndex, account.Address)
+		}
+
+		key, err := findAccountKey(account, keyIndex)
+		if err == nil {
+			var valid bool
+			valid, err = verifyAccountKeySignature(key, sigs[i], message)
+			if err == nil && !valid {
+				err = fmt.Errorf("signature does not match key %d", keyIndex)
+			}
+		}
+		if err != nil {
+			if anyKey {
+				continue
+			}
+			return fmt.Errorf("%w from key %d of %s: %s", ErrInvalidSignature, keyIndex, account.Address, err)
+		}
+
+		signed[keyIndex] = true
+		weight += key.Weight
+	}
+
+	if weight < AccountKeyWeightThreshold {
+		return newInsufficientKeyWeightError("account", account.Address, weight)
+	}
+
+	return nil
 }
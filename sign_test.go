@@ -0,0 +1,93 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestVerifyUserSignature(t *testing.T) {
+	message := []byte("login")
+
+	sign := func(t *testing.T, a *signingAccount, keyIndices ...int) [][]byte {
+		sigs := make([][]byte, len(keyIndices))
+		for i, keyIndex := range keyIndices {
+			sig, err := flow.SignUserMessage(a.signers[keyIndex], message)
+			require.NoError(t, err)
+			sigs[i] = sig
+		}
+		return sigs
+	}
+
+	t.Run("All keys", func(t *testing.T) {
+		a := newSigningAccounts([]int{500, 500, 1000})[0]
+
+		assert.NoError(t, flow.VerifyUserSignature(a.account, message, sign(t, a, 0, 1), []int{0, 1}))
+		assert.NoError(t, flow.VerifyUserSignature(a.account, message, sign(t, a, 2), []int{2}))
+
+		err := flow.VerifyUserSignature(a.account, message, sign(t, a, 0), []int{0})
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+
+		err = flow.VerifyUserSignature(a.account, message, sign(t, a, 0, 0), []int{0, 0})
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+
+		err = flow.VerifyUserSignature(a.account, message, sign(t, a, 2, 0), []int{2, 1})
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+
+		err = flow.VerifyUserSignature(a.account, []byte("other"), sign(t, a, 2), []int{2})
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+
+		err = flow.VerifyUserSignature(a.account, message, sign(t, a, 2), []int{3})
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+
+		assert.Error(t, flow.VerifyUserSignature(a.account, message, sign(t, a, 2), nil))
+	})
+
+	t.Run("Any key", func(t *testing.T) {
+		a := newSigningAccounts([]int{500, 500, 1000})[0]
+
+		err := flow.VerifyUserSignatureAnyKey(a.account, message, sign(t, a, 2, 0), []int{2, 1})
+		assert.NoError(t, err)
+
+		err = flow.VerifyUserSignatureAnyKey(a.account, message, sign(t, a, 0, 1, 1), []int{0, 1, 1})
+		assert.NoError(t, err)
+
+		err = flow.VerifyUserSignatureAnyKey(a.account, message, sign(t, a, 0, 0), []int{0, 0})
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		a := newSigningAccounts([]int{1000, 1000})[0]
+		a.account.Keys[0].Revoked = true
+
+		err := flow.VerifyUserSignature(a.account, message, sign(t, a, 0), []int{0})
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+
+		err = flow.VerifyUserSignatureAnyKey(a.account, message, sign(t, a, 0), []int{0})
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+
+		err = flow.VerifyUserSignatureAnyKey(a.account, message, sign(t, a, 0, 1), []int{0, 1})
+		assert.NoError(t, err)
+	})
+}
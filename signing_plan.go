@@ -0,0 +1,255 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// ErrNotTransactionSigner is returned when a signing plan is applied to a transaction that does
+// not require a signature from the planned account.
+var ErrNotTransactionSigner = errors.New("account is not a signer of the transaction")
+
+// KeySigners maps public keys to the signers that can sign with them.
+//
+// The map is keyed by the string representation of the public key, as returned by
+// crypto.PublicKey.String.
+type KeySigners map[string]crypto.Signer
+
+// NewKeySigners returns the signers keyed by their public keys.
+func NewKeySigners(signers ...crypto.Signer) KeySigners {
+	keySigners := make(KeySigners, len(signers))
+	for _, signer := range signers {
+		keySigners[signer.PublicKey().String()] = signer
+	}
+
+	return keySigners
+}
+
+// A PlannedKey is an account key paired with the signer for its public key.
+type PlannedKey struct {
+	Key    *AccountKey
+	Signer crypto.Signer
+}
+
+// A SigningPlan is a set of keys of an account that together reach AccountKeyWeightThreshold.
+type SigningPlan struct {
+	Address Address
+	Keys    []PlannedKey
+
+	// candidates are all usable keys of the account, ordered by decreasing weight
+	candidates []PlannedKey
+}
+
+// PlanSigningKeys selects the keys of an account to sign with, given the available signers.
+//
+// Revoked keys and keys without a matching signer are skipped. A signer matches a key if it
+// has the same public key and signature algorithm, and, if it is a crypto.HashingSigner that
+// reports a hash algorithm other than crypto.UnknownHashAlgorithm, the same hash algorithm. The
+// planner selects the fewest keys whose total weight reaches AccountKeyWeightThreshold,
+// preferring keys with lower indices among keys of equal weight.
+//
+// An error wrapping ErrInsufficientKeyWeight is returned if the usable keys do not reach the
+// threshold.
+func PlanSigningKeys(account *Account, signers KeySigners) (*SigningPlan, error) {
+	var candidates []PlannedKey
+
+	for _, key := range account.Keys {
+		if key.Revoked || key.PublicKey == nil {
+			continue
+		}
+
+		signer, ok := signers[key.PublicKey.String()]
+		if !ok || !signerMatchesKey(signer, key) {
+			continue
+		}
+
+		candidates = append(candidates, PlannedKey{Key: key, Signer: signer})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Key.Weight != candidates[j].Key.Weight {
+			return candidates[i].Key.Weight > candidates[j].Key.Weight
+		}
+		return candidates[i].Key.Index < candidates[j].Key.Index
+	})
+
+	keys, err := selectSigningKeys(account.Address, candidates, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SigningPlan{
+		Address:    account.Address,
+		Keys:       keys,
+		candidates: candidates,
+	}, nil
+}
+
+func signerMatchesKey(signer crypto.Signer, key *AccountKey) bool {
+	if signer.PublicKey().Algorithm() != key.SigAlgo {
+		return false
+	}
+
+	if hashingSigner, ok := signer.(crypto.HashingSigner); ok {
+		hashAlgo := hashingSigner.HashAlgorithm()
+		if hashAlgo != crypto.UnknownHashAlgorithm {
+			return hashAlgo == key.HashAlgo
+		}
+	}
+
+	return true
+}
+
+// selectSigningKeys selects the fewest candidates that reach AccountKeyWeightThreshold.
+//
+// If requiredIndex is not negative, the key with that index is always selected.
+func selectSigningKeys(address Address, candidates []PlannedKey, requiredIndex int) ([]PlannedKey, error) {
+	var keys []PlannedKey
+	weight := 0
+
+	if requiredIndex >= 0 {
+		for _, candidate := range candidates {
+			if candidate.Key.Index == requiredIndex {
+				keys = append(keys, candidate)
+				weight += candidate.Key.Weight
+				break
+			}
+		}
+
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("%w: no signer for key %d of %s", ErrMissingProposalKeySignature, requiredIndex, address)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if weight >= AccountKeyWeightThreshold {
+			break
+		}
+
+		if candidate.Key.Index == requiredIndex {
+			continue
+		}
+
+		keys = append(keys, candidate)
+		weight += candidate.Key.Weight
+	}
+
+	if weight < AccountKeyWeightThreshold {
+		return nil, newInsufficientKeyWeightError("account", address, weight)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key.Index < keys[j].Key.Index
+	})
+
+	return keys, nil
+}
+
+// KeyIndices returns the indices of the selected keys.
+func (p *SigningPlan) KeyIndices() []int {
+	indices := make([]int, len(p.Keys))
+	for i, key := range p.Keys {
+		indices[i] = key.Key.Index
+	}
+
+	return indices
+}
+
+// SigningKeys returns the selected keys, e.g. to create a PartiallySignedTransaction.
+func (p *SigningPlan) SigningKeys() []SigningKey {
+	keys := make([]SigningKey, len(p.Keys))
+	for i, key := range p.Keys {
+		keys[i] = SigningKey{Address: p.Address, KeyIndex: key.Key.Index}
+	}
+
+	return keys
+}
+
+// SignTransaction signs the transaction with the selected keys in the role of the planned account.
+//
+// If the account is the payer, the envelope is signed; this must be done after all payload
+// signatures have been added. Otherwise, if the account is the proposer or an authorizer, the
+// payload is signed. If the account is the proposer, the proposal key is signed with as well,
+// selecting a different set of keys if necessary.
+//
+// An error wrapping ErrNotTransactionSigner is returned if the account has no role in the
+// transaction.
+func (p *SigningPlan) SignTransaction(tx *Transaction) error {
+	return p.SignTransactionWithContext(context.Background(), tx)
+}
+
+// SignTransactionWithContext signs the transaction like SignTransaction, passing the context
+// to signers that are a crypto.ContextSigner.
+func (p *SigningPlan) SignTransactionWithContext(ctx context.Context, tx *Transaction) error {
+	isPayer := p.Address == tx.Payer
+	isProposer := p.Address == tx.ProposalKey.Address
+
+	if !isPayer && !isProposer && !isAuthorizer(tx, p.Address) {
+		return fmt.Errorf("%w: %s", ErrNotTransactionSigner, p.Address)
+	}
+
+	keys := p.Keys
+	if isProposer && !p.hasKey(tx.ProposalKey.KeyIndex) {
+		var err error
+		keys, err = selectSigningKeys(p.Address, p.candidates, tx.ProposalKey.KeyIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range keys {
+		var err error
+		if isPayer {
+			err = tx.SignEnvelopeWithContext(ctx, p.Address, key.Key.Index, key.Signer)
+		} else {
+			err = tx.SignPayloadWithContext(ctx, p.Address, key.Key.Index, key.Signer)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to sign with key %d of %s: %w", key.Key.Index, p.Address, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *SigningPlan) hasKey(keyIndex int) bool {
+	for _, key := range p.Keys {
+		if key.Key.Index == keyIndex {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAuthorizer(tx *Transaction, address Address) bool {
+	for _, authorizer := range tx.Authorizers {
+		if authorizer == address {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestPlanSigningKeys(t *testing.T) {
+	t.Run("Fewest keys", func(t *testing.T) {
+		a := newSigningAccounts([]int{300, 500, 500, 1000})[0]
+
+		plan, err := flow.PlanSigningKeys(a.account, flow.NewKeySigners(a.signers...))
+		require.NoError(t, err)
+
+		assert.Equal(t, a.account.Address, plan.Address)
+		assert.Equal(t, []int{3}, plan.KeyIndices())
+		assert.Equal(t, []flow.SigningKey{{Address: a.account.Address, KeyIndex: 3}}, plan.SigningKeys())
+	})
+
+	t.Run("Equal weights prefer lower indices", func(t *testing.T) {
+		a := newSigningAccounts([]int{250, 500, 500, 500})[0]
+
+		plan, err := flow.PlanSigningKeys(a.account, flow.NewKeySigners(a.signers...))
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{1, 2}, plan.KeyIndices())
+	})
+
+	t.Run("Skips revoked keys and keys without signer", func(t *testing.T) {
+		a := newSigningAccounts([]int{1000, 1000, 500, 500})[0]
+		a.account.Keys[0].Revoked = true
+
+		plan, err := flow.PlanSigningKeys(a.account, flow.NewKeySigners(a.signers[0], a.signers[2], a.signers[3]))
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{2, 3}, plan.KeyIndices())
+	})
+
+	t.Run("Skips keys with mismatched algorithms", func(t *testing.T) {
+		a := newSigningAccounts([]int{1000, 1000})[0]
+		a.account.Keys[0].HashAlgo = crypto.SHA2_256
+
+		plan, err := flow.PlanSigningKeys(a.account, flow.NewKeySigners(a.signers...))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, plan.KeyIndices())
+
+		a.account.Keys[1].SigAlgo = crypto.ECDSA_secp256k1
+
+		_, err = flow.PlanSigningKeys(a.account, flow.NewKeySigners(a.signers...))
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		a := newSigningAccounts([]int{500, 499})[0]
+
+		_, err := flow.PlanSigningKeys(a.account, flow.NewKeySigners(a.signers...))
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+	})
+}
+
+func TestSigningPlan_SignTransaction(t *testing.T) {
+	newTransaction := func(proposalKeyIndex int) (*flow.Transaction, *signingAccount, *signingAccount) {
+		accounts := newSigningAccounts([]int{250, 500, 500}, []int{500, 500, 1000})
+		authorizer, payer := accounts[0], accounts[1]
+
+		tx := flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(authorizer.account.Address, proposalKeyIndex, 0).
+			SetPayer(payer.account.Address).
+			AddAuthorizer(authorizer.account.Address)
+
+		return tx, authorizer, payer
+	}
+
+	sign := func(t *testing.T, tx *flow.Transaction, accounts ...*signingAccount) {
+		for _, a := range accounts {
+			plan, err := flow.PlanSigningKeys(a.account, flow.NewKeySigners(a.signers...))
+			require.NoError(t, err)
+			require.NoError(t, plan.SignTransaction(tx))
+		}
+	}
+
+	t.Run("Signs payload and envelope", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction(1)
+		sign(t, tx, authorizer, payer)
+
+		require.Len(t, tx.PayloadSignatures, 2)
+		assert.Equal(t, 1, tx.PayloadSignatures[0].KeyIndex)
+		assert.Equal(t, 2, tx.PayloadSignatures[1].KeyIndex)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, 2, tx.EnvelopeSignatures[0].KeyIndex)
+
+		assert.NoError(t, tx.VerifySignatures(lookupAccounts(authorizer, payer)))
+	})
+
+	t.Run("Includes proposal key", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction(0)
+		sign(t, tx, authorizer, payer)
+
+		require.Len(t, tx.PayloadSignatures, 3)
+		assert.Equal(t, 0, tx.PayloadSignatures[0].KeyIndex)
+
+		assert.NoError(t, tx.VerifySignatures(lookupAccounts(authorizer, payer)))
+	})
+
+	t.Run("Missing proposal key signer", func(t *testing.T) {
+		tx, authorizer, _ := newTransaction(0)
+
+		plan, err := flow.PlanSigningKeys(authorizer.account, flow.NewKeySigners(authorizer.signers[1:]...))
+		require.NoError(t, err)
+
+		err = plan.SignTransaction(tx)
+		assert.ErrorIs(t, err, flow.ErrMissingProposalKeySignature)
+		assert.Empty(t, tx.PayloadSignatures)
+	})
+
+	t.Run("Not a signer", func(t *testing.T) {
+		tx, _, _ := newTransaction(1)
+		other := newSigningAccounts([]int{1000})[0]
+		other.account.Address = flow.HexToAddress("0x0000000000000042")
+
+		plan, err := flow.PlanSigningKeys(other.account, flow.NewKeySigners(other.signers...))
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, plan.SignTransaction(tx), flow.ErrNotTransactionSigner)
+	})
+}
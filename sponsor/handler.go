@@ -0,0 +1,318 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sponsor provides an HTTP handler that pays the fees of transactions sent by other accounts.
+//
+// A client builds a transaction with the sponsor account as payer, collects the payload
+// signatures of the proposer and authorizers, and posts it as a JSON-encoded
+// flow.PartiallySignedTransaction. The handler validates the transaction, verifies the payload
+// signatures, checks the transaction against the sponsor policy and quota, and adds the envelope
+// signature of the sponsor. The signed transaction is then returned to the client or submitted to the network.
+package sponsor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/policy"
+)
+
+// DefaultMaxRequestSize is the default maximum size of a request body.
+//
+// It leaves room for the JSON and base64 encoding of a transaction of the maximum size.
+const DefaultMaxRequestSize = 4 * flow.DefaultMaxTransactionByteSize
+
+var (
+	// ErrNotSponsored is returned when the payer of a transaction is not the sponsor account.
+	ErrNotSponsored = errors.New("transaction is not paid by the sponsor")
+	// ErrSponsorSigner is returned when the sponsor account is the proposer or an authorizer of a transaction.
+	ErrSponsorSigner = errors.New("sponsor cannot propose or authorize transactions")
+	// ErrQuotaExceeded is returned when the proposer of a transaction has exceeded its quota.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+// Client is the subset of the access API used by the Handler.
+type Client interface {
+	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
+	SendTransaction(ctx context.Context, tx flow.Transaction) error
+}
+
+// A Quota limits the number of transactions sponsored for each user.
+type Quota interface {
+	// Allow reports whether another transaction proposed by the given account may be sponsored,
+	// and if so, counts it against the quota of the account.
+	Allow(address flow.Address) bool
+	// Refund gives back a transaction counted by Allow that was not sponsored after all,
+	// because signing or submitting it failed.
+	Refund(address flow.Address)
+}
+
+// An Account is the sponsor account key that signs transaction envelopes.
+type Account struct {
+	Address  flow.Address
+	KeyIndex int
+	Signer   crypto.Signer
+}
+
+// A Response is the response to a successfully sponsored transaction.
+type Response struct {
+	// TransactionID is the hex-encoded ID of the signed transaction.
+	TransactionID string `json:"transactionId"`
+	// Transaction is the signed transaction, as returned by Transaction.Encode.
+	Transaction []byte `json:"transaction"`
+	// Submitted is true if the transaction was submitted to the network.
+	Submitted bool `json:"submitted"`
+}
+
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// A Handler is an http.Handler that adds the sponsor envelope signature to transactions.
+//
+// Requests must be POST requests with a JSON-encoded flow.PartiallySignedTransaction as body.
+// Successful requests are answered with a JSON-encoded Response. Failed requests are answered
+// with a JSON object holding the status code and an error message: 400 for malformed or invalid
+// transactions, 403 for transactions rejected by the policy, 429 if the quota is exceeded and
+// 502 if the access node cannot be reached.
+//
+// A Handler is safe for concurrent use if its signer and quota are.
+type Handler struct {
+	client         Client
+	sponsor        Account
+	policy         policy.Policy
+	quota          Quota
+	submit         bool
+	maxRequestSize int64
+}
+
+// An Option configures a Handler.
+type Option func(*Handler)
+
+// WithPolicy sets the policy that transactions must satisfy to be sponsored.
+func WithPolicy(p policy.Policy) Option {
+	return func(h *Handler) {
+		h.policy = p
+	}
+}
+
+// WithQuota sets the quota that limits the transactions sponsored for each proposer account.
+//
+// A transaction only counts against the quota once it has passed all other checks, and is
+// refunded if signing or submitting it fails.
+func WithQuota(quota Quota) Option {
+	return func(h *Handler) {
+		h.quota = quota
+	}
+}
+
+// WithSubmission makes the handler submit signed transactions to the network instead of
+// only returning them.
+func WithSubmission() Option {
+	return func(h *Handler) {
+		h.submit = true
+	}
+}
+
+// WithMaxRequestSize sets the maximum size of a request body.
+func WithMaxRequestSize(size int64) Option {
+	return func(h *Handler) {
+		h.maxRequestSize = size
+	}
+}
+
+// NewHandler creates a handler that sponsors transactions with the given account key.
+func NewHandler(client Client, sponsor Account, opts ...Option) *Handler {
+	h := &Handler{
+		client:         client,
+		sponsor:        sponsor,
+		maxRequestSize: DefaultMaxRequestSize,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// ServeHTTP sponsors the transaction in the request body.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to read request: %w", err))
+		return
+	}
+
+	var partial flow.PartiallySignedTransaction
+	err = json.Unmarshal(body, &partial)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to decode partially signed transaction: %w", err))
+		return
+	}
+
+	tx, err := partial.DecodeTransaction()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	status, err := h.Sponsor(r.Context(), tx)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{
+		TransactionID: tx.ID().String(),
+		Transaction:   tx.Encode(),
+		Submitted:     h.submit,
+	})
+}
+
+// Sponsor checks the transaction, adds the sponsor envelope signature and, if the handler
+// was created WithSubmission, submits it.
+//
+// On failure, the HTTP status code matching the error is returned along with the error.
+func (h *Handler) Sponsor(ctx context.Context, tx *flow.Transaction) (int, error) {
+	if tx.Payer != h.sponsor.Address {
+		return http.StatusForbidden, fmt.Errorf("%w: payer is %s", ErrNotSponsored, tx.Payer)
+	}
+
+	if tx.ProposalKey.Address == h.sponsor.Address || containsAddress(tx.Authorizers, h.sponsor.Address) {
+		return http.StatusForbidden, ErrSponsorSigner
+	}
+
+	if len(tx.EnvelopeSignatures) > 0 {
+		return http.StatusBadRequest, errors.New("transaction envelope is already signed")
+	}
+
+	err := validate(tx)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = h.policy.Check(tx)
+	if err != nil {
+		return http.StatusForbidden, err
+	}
+
+	var lookupErr error
+	err = tx.VerifyPayloadSignatures(func(address flow.Address) (*flow.Account, error) {
+		account, err := h.client.GetAccountAtLatestBlock(ctx, address)
+		if err != nil {
+			lookupErr = err
+		}
+		return account, err
+	})
+	if lookupErr != nil {
+		return http.StatusBadGateway, err
+	}
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if h.quota != nil && !h.quota.Allow(tx.ProposalKey.Address) {
+		return http.StatusTooManyRequests, fmt.Errorf("%w: %s", ErrQuotaExceeded, tx.ProposalKey.Address)
+	}
+
+	status, err := h.signAndSubmit(ctx, tx)
+	if err != nil && h.quota != nil {
+		h.quota.Refund(tx.ProposalKey.Address)
+	}
+
+	return status, err
+}
+
+// signAndSubmit adds the sponsor envelope signature and, if the handler was created
+// WithSubmission, submits the transaction.
+func (h *Handler) signAndSubmit(ctx context.Context, tx *flow.Transaction) (int, error) {
+	err := tx.SignEnvelopeWithContext(ctx, h.sponsor.Address, h.sponsor.KeyIndex, h.sponsor.Signer)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to sign envelope: %w", err)
+	}
+
+	if h.submit {
+		err = h.client.SendTransaction(ctx, *tx)
+		if err != nil {
+			return http.StatusBadGateway, fmt.Errorf("failed to submit transaction: %w", err)
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// validate performs the pre-flight checks of Transaction.Validate, except for the payer
+// signature that is only added by the sponsor.
+func validate(tx *flow.Transaction) error {
+	err := tx.Validate(flow.WithCanonicalArguments())
+
+	var validationErr flow.TransactionValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	var errs []error
+	for _, err := range validationErr.Errors {
+		if !errors.Is(err, flow.ErrMissingPayerSignature) {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return flow.TransactionValidationError{Errors: errs}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{
+		Code:    status,
+		Message: err.Error(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func containsAddress(addresses []flow.Address, address flow.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,355 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sponsor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	flowhttp "github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/policy"
+	"github.com/onflow/flow-go-sdk/sponsor"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	_ sponsor.Client = (*grpc.Client)(nil)
+	_ sponsor.Client = (*flowhttp.Client)(nil)
+)
+
+type mockClient struct {
+	accounts map[flow.Address]*flow.Account
+	sent     []flow.Transaction
+	sendErr  error
+}
+
+func (c *mockClient) GetAccountAtLatestBlock(_ context.Context, address flow.Address) (*flow.Account, error) {
+	account, ok := c.accounts[address]
+	if !ok {
+		return nil, errors.New("account not found")
+	}
+	return account, nil
+}
+
+func (c *mockClient) SendTransaction(_ context.Context, tx flow.Transaction) error {
+	if c.sendErr != nil {
+		return c.sendErr
+	}
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+type fixture struct {
+	client     *mockClient
+	sponsor    sponsor.Account
+	user       flow.Address
+	userSigner crypto.Signer
+	userKeyIdx int
+}
+
+func newFixture() *fixture {
+	addresses := test.AddressGenerator()
+	keys := test.AccountKeyGenerator()
+
+	userKey, userSigner := keys.NewWithSigner()
+	sponsorKey, sponsorSigner := keys.NewWithSigner()
+
+	user := &flow.Account{Address: addresses.New(), Keys: []*flow.AccountKey{userKey}}
+	sponsorAccount := &flow.Account{Address: addresses.New(), Keys: []*flow.AccountKey{sponsorKey}}
+
+	return &fixture{
+		client: &mockClient{
+			accounts: map[flow.Address]*flow.Account{
+				user.Address:           user,
+				sponsorAccount.Address: sponsorAccount,
+			},
+		},
+		sponsor: sponsor.Account{
+			Address:  sponsorAccount.Address,
+			KeyIndex: sponsorKey.Index,
+			Signer:   sponsorSigner,
+		},
+		user:       user.Address,
+		userSigner: userSigner,
+		userKeyIdx: userKey.Index,
+	}
+}
+
+func (f *fixture) newTransaction(t *testing.T) *flow.Transaction {
+	tx := flow.NewTransaction().
+		SetScript(test.GreetingScript).
+		SetGasLimit(100).
+		SetReferenceBlockID(test.IdentifierGenerator().New()).
+		SetProposalKey(f.user, f.userKeyIdx, 42).
+		SetPayer(f.sponsor.Address).
+		AddAuthorizer(f.user)
+
+	require.NoError(t, tx.SignPayload(f.user, f.userKeyIdx, f.userSigner))
+
+	return tx
+}
+
+func (f *fixture) post(t *testing.T, handler http.Handler, tx *flow.Transaction) *httptest.ResponseRecorder {
+	partial, err := flow.NewPartiallySignedTransaction(tx)
+	require.NoError(t, err)
+
+	body, err := partial.Encode()
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+	return rec
+}
+
+func (f *fixture) lookup(address flow.Address) (*flow.Account, error) {
+	return f.client.GetAccountAtLatestBlock(context.Background(), address)
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("Returns signed transaction", func(t *testing.T) {
+		f := newFixture()
+		handler := sponsor.NewHandler(f.client, f.sponsor)
+
+		rec := f.post(t, handler, f.newTransaction(t))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var res sponsor.Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.False(t, res.Submitted)
+
+		tx, err := flow.DecodeTransaction(res.Transaction)
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID().String(), res.TransactionID)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, f.sponsor.Address, tx.EnvelopeSignatures[0].Address)
+		assert.NoError(t, tx.VerifySignatures(f.lookup))
+
+		assert.Empty(t, f.client.sent)
+	})
+
+	t.Run("Submits signed transaction", func(t *testing.T) {
+		f := newFixture()
+		handler := sponsor.NewHandler(f.client, f.sponsor, sponsor.WithSubmission())
+
+		rec := f.post(t, handler, f.newTransaction(t))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var res sponsor.Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.True(t, res.Submitted)
+
+		require.Len(t, f.client.sent, 1)
+		assert.Equal(t, res.TransactionID, f.client.sent[0].ID().String())
+	})
+
+	t.Run("Submission failure", func(t *testing.T) {
+		f := newFixture()
+		f.client.sendErr = errors.New("unavailable")
+		handler := sponsor.NewHandler(f.client, f.sponsor, sponsor.WithSubmission())
+
+		rec := f.post(t, handler, f.newTransaction(t))
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.Contains(t, rec.Body.String(), "unavailable")
+	})
+
+	t.Run("Rejections", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			opts   []sponsor.Option
+			modify func(f *fixture, tx *flow.Transaction) *flow.Transaction
+			status int
+		}{
+			{
+				name: "Other payer",
+				modify: func(f *fixture, tx *flow.Transaction) *flow.Transaction {
+					tx.SetPayer(f.user)
+					return tx
+				},
+				status: http.StatusForbidden,
+			},
+			{
+				name: "Sponsor authorizer",
+				modify: func(f *fixture, tx *flow.Transaction) *flow.Transaction {
+					tx.AddAuthorizer(f.sponsor.Address)
+					return tx
+				},
+				status: http.StatusForbidden,
+			},
+			{
+				name: "Missing payload signature",
+				modify: func(_ *fixture, tx *flow.Transaction) *flow.Transaction {
+					tx.PayloadSignatures = nil
+					return tx
+				},
+				status: http.StatusBadRequest,
+			},
+			{
+				name: "Invalid payload signature",
+				modify: func(_ *fixture, tx *flow.Transaction) *flow.Transaction {
+					return tx.SetGasLimit(200)
+				},
+				status: http.StatusBadRequest,
+			},
+			{
+				name: "Duplicate payload signature",
+				modify: func(f *fixture, tx *flow.Transaction) *flow.Transaction {
+					f.client.accounts[f.user].Keys[0].Weight = 500
+					require.NoError(t, tx.SignPayload(f.user, f.userKeyIdx, f.userSigner))
+					return tx
+				},
+				status: http.StatusBadRequest,
+			},
+			{
+				name: "Non-canonical argument",
+				modify: func(f *fixture, tx *flow.Transaction) *flow.Transaction {
+					tx.Arguments = append(tx.Arguments, []byte(`{ "type": "String", "value": "hello" }`))
+					tx.PayloadSignatures = nil
+					require.NoError(t, tx.SignPayload(f.user, f.userKeyIdx, f.userSigner))
+					return tx
+				},
+				status: http.StatusBadRequest,
+			},
+			{
+				name: "Signed envelope",
+				modify: func(f *fixture, tx *flow.Transaction) *flow.Transaction {
+					require.NoError(t, tx.SignEnvelope(f.sponsor.Address, f.sponsor.KeyIndex, f.sponsor.Signer))
+					return tx
+				},
+				status: http.StatusBadRequest,
+			},
+			{
+				name: "Script not allowed",
+				opts: []sponsor.Option{
+					sponsor.WithPolicy(policy.Policy{ScriptHashes: []string{policy.ScriptHash([]byte("other"))}}),
+				},
+				status: http.StatusForbidden,
+			},
+			{
+				name: "Gas limit",
+				opts: []sponsor.Option{
+					sponsor.WithPolicy(policy.Policy{MaxGasLimit: 99}),
+				},
+				status: http.StatusForbidden,
+			},
+			{
+				name: "Quota",
+				opts: []sponsor.Option{
+					sponsor.WithQuota(sponsor.NewWindowQuota(0, time.Hour)),
+				},
+				status: http.StatusTooManyRequests,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				f := newFixture()
+				handler := sponsor.NewHandler(f.client, f.sponsor, tt.opts...)
+
+				tx := f.newTransaction(t)
+				if tt.modify != nil {
+					tx = tt.modify(f, tx)
+				}
+
+				rec := f.post(t, handler, tx)
+				assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+
+				var res struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				assert.Equal(t, tt.status, res.Code)
+				assert.NotEmpty(t, res.Message)
+			})
+		}
+	})
+
+	t.Run("Quota per proposer", func(t *testing.T) {
+		f := newFixture()
+		handler := sponsor.NewHandler(f.client, f.sponsor, sponsor.WithQuota(sponsor.NewWindowQuota(1, time.Hour)))
+
+		assert.Equal(t, http.StatusOK, f.post(t, handler, f.newTransaction(t)).Code)
+		assert.Equal(t, http.StatusTooManyRequests, f.post(t, handler, f.newTransaction(t)).Code)
+	})
+
+	t.Run("Rejected transactions do not use quota", func(t *testing.T) {
+		f := newFixture()
+		handler := sponsor.NewHandler(f.client, f.sponsor, sponsor.WithQuota(sponsor.NewWindowQuota(1, time.Hour)))
+
+		tx := f.newTransaction(t)
+		tx.Arguments = append(tx.Arguments, []byte(`{ "type": "String", "value": "hello" }`))
+		tx.PayloadSignatures = nil
+		require.NoError(t, tx.SignPayload(f.user, f.userKeyIdx, f.userSigner))
+
+		rec := f.post(t, handler, tx)
+		require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), flow.ErrNonCanonicalArgument.Error())
+
+		assert.Equal(t, http.StatusOK, f.post(t, handler, f.newTransaction(t)).Code)
+	})
+
+	t.Run("Failed submissions do not use quota", func(t *testing.T) {
+		f := newFixture()
+		handler := sponsor.NewHandler(
+			f.client,
+			f.sponsor,
+			sponsor.WithSubmission(),
+			sponsor.WithQuota(sponsor.NewWindowQuota(1, time.Hour)),
+		)
+
+		f.client.sendErr = errors.New("unavailable")
+		assert.Equal(t, http.StatusBadGateway, f.post(t, handler, f.newTransaction(t)).Code)
+
+		f.client.sendErr = nil
+		assert.Equal(t, http.StatusOK, f.post(t, handler, f.newTransaction(t)).Code)
+		assert.Equal(t, http.StatusTooManyRequests, f.post(t, handler, f.newTransaction(t)).Code)
+	})
+
+	t.Run("Malformed requests", func(t *testing.T) {
+		f := newFixture()
+		handler := sponsor.NewHandler(f.client, f.sponsor, sponsor.WithMaxRequestSize(1024))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{"))))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(make([]byte, 2048))))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
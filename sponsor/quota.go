@@ -0,0 +1,87 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sponsor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A WindowQuota allows a fixed number of transactions per account in each time window.
+//
+// Windows are aligned to multiples of the window duration, so that all counters are reset
+// at the same time. A WindowQuota is safe for concurrent use.
+type WindowQuota struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[flow.Address]int
+}
+
+var _ Quota = (*WindowQuota)(nil)
+
+// NewWindowQuota creates a quota that allows limit transactions per account in each window.
+func NewWindowQuota(limit int, window time.Duration) *WindowQuota {
+	return &WindowQuota{
+		limit:  limit,
+		window: window,
+		now:    time.Now,
+		counts: make(map[flow.Address]int),
+	}
+}
+
+// Allow reports whether the account has transactions left in the current window, and if so,
+// counts one against its quota.
+func (q *WindowQuota) Allow(address flow.Address) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	start := q.now().Truncate(q.window)
+	if !start.Equal(q.start) {
+		q.start = start
+		q.counts = make(map[flow.Address]int)
+	}
+
+	if q.counts[address] >= q.limit {
+		return false
+	}
+
+	q.counts[address]++
+	return true
+}
+
+// Refund gives back a transaction counted against the quota of the account in the current window.
+func (q *WindowQuota) Refund(address flow.Address) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// counters of a previous window have already been reset
+	if !q.now().Truncate(q.window).Equal(q.start) {
+		return
+	}
+
+	if q.counts[address] > 0 {
+		q.counts[address]--
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sponsor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestWindowQuota(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	quota := NewWindowQuota(2, time.Minute)
+	quota.now = func() time.Time { return now }
+
+	a := flow.HexToAddress("0x01")
+	b := flow.HexToAddress("0x02")
+
+	assert.True(t, quota.Allow(a))
+	assert.True(t, quota.Allow(a))
+	assert.False(t, quota.Allow(a))
+	assert.True(t, quota.Allow(b))
+
+	now = now.Add(59 * time.Second)
+	assert.False(t, quota.Allow(a))
+
+	now = now.Add(time.Second)
+	assert.True(t, quota.Allow(a))
+	assert.True(t, quota.Allow(b))
+	assert.True(t, quota.Allow(b))
+	assert.False(t, quota.Allow(b))
+
+	quota.Refund(b)
+	assert.True(t, quota.Allow(b))
+	assert.False(t, quota.Allow(b))
+
+	// a refund after the window ends does not carry over
+	now = now.Add(time.Minute)
+	quota.Refund(a)
+	assert.True(t, quota.Allow(a))
+	assert.True(t, quota.Allow(a))
+	assert.False(t, quota.Allow(a))
+}
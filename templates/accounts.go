@@ -21,7 +21,6 @@ package templates
 import (
 	"encoding/hex"
 	"fmt"
-	"strings"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
@@ -32,6 +31,8 @@ import (
 	templates "github.com/onflow/sdks"
 
 	"github.com/onflow/flow-go-sdk"
+	corecontracts "github.com/onflow/flow-go-sdk/contracts"
+	"github.com/onflow/flow-go-sdk/imports"
 )
 
 // Contract is a Cadence contract deployed to a Flow account.
@@ -184,6 +185,11 @@ func CreateAccount(accountKeys []*flow.AccountKey, contracts []Contract, payer f
 	return CreateAccountAndFund(accountKeys, contracts, payer, "", "")
 }
 
+// CreateAccountAndFund generates a transaction that creates a new account and funds it
+// with the given amount of FLOW from the payer.
+//
+// The FlowToken and FungibleToken imports are resolved to the core contract addresses on
+// the given network. If amount is empty, the account is not funded.
 func CreateAccountAndFund(
 	accountKeys []*flow.AccountKey,
 	contracts []Contract,
@@ -219,28 +225,24 @@ func CreateAccountAndFund(
 		jsoncdc.MustEncode(cadenceContracts),
 	}
 
-	// if we have provided amount and network then we do funding as well
-	if amount != "" && network == flow.Mainnet || network == flow.Testnet {
+	// if we have provided an amount then we do funding as well
+	if amount != "" {
 		script = templates.CreateAccountFunding
-		// replace the imports on supported networks
-		replaces := map[flow.ChainID]map[string]string{
-			flow.Testnet: {
-				"FlowToken":     "0x7e60df042a9c0868", // https://developers.flow.com/flow/core-contracts/flow-token
-				"FungibleToken": "0x9a0766d93b6608b7", // https://developers.flow.com/flow/core-contracts/fungible-token
-			},
-			flow.Mainnet: {
-				"FlowToken":     "0x1654653399040a61",
-				"FungibleToken": "0xf233dcee88fe0abe",
-			},
-		}[network]
-
-		for contract, address := range replaces {
-			script = strings.ReplaceAll(
-				script,
-				fmt.Sprintf(`"%s"`, contract),
-				fmt.Sprintf(`%s from %s`, contract, address),
-			)
+
+		registry, err := corecontracts.ForChain(network)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create CreateAccount transaction: %w", err)
+		}
+
+		resolver := imports.NewResolver(map[flow.ChainID]imports.Aliases{
+			network: registry.Aliases(),
+		})
+
+		resolved, err := resolver.Resolve(network, []byte(script))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create CreateAccount transaction: %w", err)
 		}
+		script = string(resolved)
 
 		val, err := cadence.NewUFix64(amount)
 		if err != nil {
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
 	"github.com/onflow/flow-go-sdk/templates"
 	"github.com/stretchr/testify/require"
 )
@@ -53,4 +54,18 @@ func TestCreateAccount(t *testing.T) {
 			"The create account argument size should not grow over "+
 				"2 times the contract code (converted to hex) + 500 bytes of extra data.")
 	})
+
+	t.Run("Funding resolves core contracts on the emulator", func(t *testing.T) {
+		tx, err := templates.CreateAccountAndFund(nil, nil, flow.HexToAddress("01"), "10.0", flow.Emulator)
+		require.NoError(t, err)
+
+		require.Len(t, tx.Arguments, 3)
+		require.Contains(t, string(tx.Script), "import FlowToken from 0x0ae53cb6e3f42a79")
+		require.Contains(t, string(tx.Script), "import FungibleToken from 0xee82856bf20e2aa6")
+	})
+
+	t.Run("Funding on an unsupported network", func(t *testing.T) {
+		_, err := templates.CreateAccountAndFund(nil, nil, flow.HexToAddress("01"), "10.0", "")
+		require.ErrorIs(t, err, contracts.ErrUnsupportedChain)
+	})
 }
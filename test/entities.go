@@ -19,7 +19,6 @@
 package test
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -425,7 +424,7 @@ func (g *TransactionResults) New() flow.TransactionResult {
 
 	return flow.TransactionResult{
 		Status: flow.TransactionStatusSealed,
-		Error:  errors.New("transaction execution error"),
+		Error:  flow.ParseTransactionExecutionError("transaction execution error"),
 		Events: []flow.Event{
 			eventA,
 			eventB,
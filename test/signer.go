@@ -18,7 +18,12 @@
 
 package test
 
-import "github.com/onflow/flow-go-sdk/crypto"
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
 
 type MockSigner []byte
 
@@ -29,3 +34,30 @@ func (s MockSigner) Sign(message []byte) ([]byte, error) {
 func (s MockSigner) PublicKey() crypto.PublicKey {
 	return nil
 }
+
+// An ExclusiveHasher wraps a hasher and records whether it was used by several goroutines at
+// the same time, which the hashers of the crypto package do not support.
+//
+// Each hash computation is slowed down, so that overlapping uses are reliably observed.
+type ExclusiveHasher struct {
+	crypto.Hasher
+
+	active     int32
+	concurrent int32
+}
+
+func (h *ExclusiveHasher) ComputeHash(data []byte) crypto.Hash {
+	if atomic.AddInt32(&h.active, 1) > 1 {
+		atomic.StoreInt32(&h.concurrent, 1)
+	}
+	defer atomic.AddInt32(&h.active, -1)
+
+	time.Sleep(time.Millisecond)
+
+	return h.Hasher.ComputeHash(data)
+}
+
+// UsedConcurrently returns true if the hasher was ever used by several goroutines at once.
+func (h *ExclusiveHasher) UsedConcurrently() bool {
+	return atomic.LoadInt32(&h.concurrent) == 1
+}
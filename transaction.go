@@ -20,11 +20,10 @@ package flow
 
 import (
 	"bytes"
-	"errors"
+	"context"
 	"fmt"
 	"sort"
 
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 
@@ -87,32 +86,16 @@ type Transaction struct {
 	EnvelopeSignatures []TransactionSignature
 }
 
-type payloadCanonicalForm struct {
-	Script                    []byte
-	Arguments                 [][]byte
-	ReferenceBlockID          []byte
-	GasLimit                  uint64
-	ProposalKeyAddress        []byte
-	ProposalKeyIndex          uint64
-	ProposalKeySequenceNumber uint64
-	Payer                     []byte
-	Authorizers               [][]byte
-}
-
-type envelopeCanonicalForm struct {
-	Payload           payloadCanonicalForm
-	PayloadSignatures []transactionSignatureCanonicalForm
-}
-
-type transactionCanonicalForm struct {
-	Payload            payloadCanonicalForm
-	PayloadSignatures  []transactionSignatureCanonicalForm
-	EnvelopeSignatures []transactionSignatureCanonicalForm
-}
-
 // DefaultTransactionGasLimit should be high enough for small transactions
 const DefaultTransactionGasLimit = 9999
 
+// DefaultTransactionExpiry is the number of blocks after its reference block during which
+// a transaction can be included in a block.
+//
+// A block with height refBlock.Height + DefaultTransactionExpiry is the last block that is
+// allowed to include a transaction.
+const DefaultTransactionExpiry = 600
+
 // NewTransaction initializes and returns an empty transaction.
 func NewTransaction() *Transaction {
 	return &Transaction{
@@ -133,9 +116,9 @@ func (t *Transaction) SetScript(script []byte) *Transaction {
 	return t
 }
 
-// AddArgument adds a Cadence argument to this transaction.
+// AddArgument adds a Cadence argument to this transaction, encoded in canonical JSON-CDC form.
 func (t *Transaction) AddArgument(arg cadence.Value) error {
-	encodedArg, err := jsoncdc.Encode(arg)
+	encodedArg, err := encodeCanonicalArgument(arg)
 	if err != nil {
 		return fmt.Errorf("failed to encode argument: %w", err)
 	}
@@ -145,11 +128,56 @@ func (t *Transaction) AddArgument(arg cadence.Value) error {
 }
 
 // AddRawArgument adds a raw JSON-CDC encoded argument to this transaction.
+//
+// The argument is normalized to its canonical form, so that semantically identical arguments
+// always produce the same transaction ID and signatures. Arguments that are not valid JSON-CDC
+// are added unchanged and reported by Validate; use AddCheckedRawArgument to reject them instead.
 func (t *Transaction) AddRawArgument(arg []byte) *Transaction {
-	t.Arguments = append(t.Arguments, arg)
+	canonicalArg, err := CanonicalArgument(arg)
+	if err != nil {
+		canonicalArg = arg
+	}
+
+	t.Arguments = append(t.Arguments, canonicalArg)
 	return t
 }
 
+// AddCheckedRawArgument adds a raw JSON-CDC encoded argument to this transaction in its
+// canonical form, like AddRawArgument.
+//
+// An error is returned, and the argument is not added, if it is not valid JSON-CDC.
+func (t *Transaction) AddCheckedRawArgument(arg []byte) error {
+	canonicalArg, err := CanonicalArgument(arg)
+	if err != nil {
+		return fmt.Errorf("failed to decode argument: %w", err)
+	}
+
+	t.Arguments = append(t.Arguments, canonicalArg)
+	return nil
+}
+
+// CanonicalArgument returns the canonical form of a JSON-CDC encoded argument.
+//
+// The canonical form is the encoding produced by the Cadence JSON encoder, without
+// trailing whitespace.
+func CanonicalArgument(arg []byte) ([]byte, error) {
+	value, err := jsoncdc.Decode(nil, arg, jsoncdc.WithAllowUnstructuredStaticTypes(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeCanonicalArgument(value)
+}
+
+func encodeCanonicalArgument(value cadence.Value) ([]byte, error) {
+	encoded, err := jsoncdc.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(encoded, "\n"), nil
+}
+
 // Argument returns the decoded argument at the given index.
 func (t *Transaction) Argument(i int, options ...jsoncdc.Option) (cadence.Value, error) {
 	if i < 0 {
@@ -182,6 +210,34 @@ func (t *Transaction) SetReferenceBlockID(blockID Identifier) *Transaction {
 	return t
 }
 
+// ExpiryHeight returns the height of the last block that is allowed to include this transaction.
+//
+// The provided header must be the header of the reference block of this transaction.
+func (t *Transaction) ExpiryHeight(referenceBlock *BlockHeader) (uint64, error) {
+	if referenceBlock.ID != t.ReferenceBlockID {
+		return 0, fmt.Errorf(
+			"block %s is not the reference block %s of the transaction",
+			referenceBlock.ID,
+			t.ReferenceBlockID,
+		)
+	}
+
+	return referenceBlock.Height + DefaultTransactionExpiry, nil
+}
+
+// IsExpired returns true if this transaction can no longer be included in a block
+// once the chain has reached the given height.
+//
+// The provided header must be the header of the reference block of this transaction.
+func (t *Transaction) IsExpired(referenceBlock *BlockHeader, height uint64) (bool, error) {
+	expiryHeight, err := t.ExpiryHeight(referenceBlock)
+	if err != nil {
+		return false, err
+	}
+
+	return height > expiryHeight, nil
+}
+
 // SetGasLimit sets the gas limit for this transaction.
 func (t *Transaction) SetGasLimit(limit uint64) *Transaction {
 	t.GasLimit = limit
@@ -291,11 +347,26 @@ func (t *Transaction) refreshSignerIndex() {
 //
 // This function returns an error if the signature cannot be generated.
 func (t *Transaction) SignPayload(address Address, keyIndex int, signer crypto.Signer) error {
+	return t.SignPayloadWithContext(context.Background(), address, keyIndex, signer)
+}
+
+// SignEnvelope signs the full transaction (TransactionDomainTag + payload + payload signatures) with the specified account key.
+//
+// The resulting signature is combined with the account address and key index before
+// being added to the transaction.
+//
+// This function returns an error if the signature cannot be generated.
+func (t *Transaction) SignEnvelope(address Address, keyIndex int, signer crypto.Signer) error {
+	return t.SignEnvelopeWithContext(context.Background(), address, keyIndex, signer)
+}
+
+// SignPayloadWithContext signs the transaction payload like SignPayload, passing the context
+// to the signer if it is a crypto.ContextSigner.
+func (t *Transaction) SignPayloadWithContext(ctx context.Context, address Address, keyIndex int, signer crypto.Signer) error {
 	message := t.PayloadMessage()
 	message = append(TransactionDomainTag[:], message...)
-	sig, err := signer.Sign(message)
+	sig, err := crypto.SignWithContext(ctx, signer, message)
 	if err != nil {
-		// TODO: wrap error
 		return err
 	}
 
@@ -304,18 +375,13 @@ func (t *Transaction) SignPayload(address Address, keyIndex int, signer crypto.S
 	return nil
 }
 
-// SignEnvelope signs the full transaction (TransactionDomainTag + payload + payload signatures) with the specified account key.
-//
-// The resulting signature is combined with the account address and key index before
-// being added to the transaction.
-//
-// This function returns an error if the signature cannot be generated.
-func (t *Transaction) SignEnvelope(address Address, keyIndex int, signer crypto.Signer) error {
+// SignEnvelopeWithContext signs the full transaction like SignEnvelope, passing the context
+// to the signer if it is a crypto.ContextSigner.
+func (t *Transaction) SignEnvelopeWithContext(ctx context.Context, address Address, keyIndex int, signer crypto.Signer) error {
 	message := t.EnvelopeMessage()
 	message = append(TransactionDomainTag[:], message...)
-	sig, err := signer.Sign(message)
+	sig, err := crypto.SignWithContext(ctx, signer, message)
 	if err != nil {
-		// TODO: wrap error
 		return err
 	}
 
@@ -358,192 +424,304 @@ func (t *Transaction) createSignature(address Address, keyIndex int, sig []byte)
 	}
 }
 
+// PayloadMessage returns the signable message for the transaction payload.
+//
+// This message is only signed by the proposer and authorizer accounts.
 func (t *Transaction) PayloadMessage() []byte {
-	temp := t.payloadCanonicalForm()
-	return mustRLPEncode(&temp)
+	buf := make([]byte, 0, rlpListSize(t.payloadContentSize()))
+	return t.appendPayload(buf)
 }
 
-func (t *Transaction) payloadCanonicalForm() payloadCanonicalForm {
-	authorizers := make([][]byte, len(t.Authorizers))
-	for i, auth := range t.Authorizers {
-		authorizers[i] = auth.Bytes()
+// payloadContentSize returns the size of the encoded payload fields, without the list header.
+func (t *Transaction) payloadContentSize() int {
+	argumentsSize := 0
+	for _, arg := range t.Arguments {
+		argumentsSize += rlpBytesSize(arg)
 	}
 
-	// note(sideninja): This is a temporary workaround until cadence defines canonical format addressing the issue https://github.com/onflow/flow-go-sdk/issues/286
-	for i, arg := range t.Arguments {
-		if arg[len(arg)-1] == byte(10) { // extra new line character
-			t.Arguments[i] = arg[:len(arg)-1]
-		}
+	addressSize := rlpBytesSize(EmptyAddress[:])
+
+	return rlpBytesSize(t.Script) +
+		rlpListSize(argumentsSize) +
+		rlpBytesSize(t.ReferenceBlockID[:]) +
+		rlpUintSize(t.GasLimit) +
+		addressSize +
+		rlpUintSize(uint64(t.ProposalKey.KeyIndex)) +
+		rlpUintSize(t.ProposalKey.SequenceNumber) +
+		addressSize +
+		rlpListSize(len(t.Authorizers)*addressSize)
+}
+
+func (t *Transaction) appendPayload(buf []byte) []byte {
+	buf = rlpAppendListHeader(buf, t.payloadContentSize())
+	buf = rlpAppendBytes(buf, t.Script)
+
+	argumentsSize := 0
+	for _, arg := range t.Arguments {
+		argumentsSize += rlpBytesSize(arg)
+	}
+
+	buf = rlpAppendListHeader(buf, argumentsSize)
+	for _, arg := range t.Arguments {
+		buf = rlpAppendBytes(buf, arg)
 	}
 
-	return payloadCanonicalForm{
-		Script:                    t.Script,
-		Arguments:                 t.Arguments,
-		ReferenceBlockID:          t.ReferenceBlockID[:],
-		GasLimit:                  t.GasLimit,
-		ProposalKeyAddress:        t.ProposalKey.Address.Bytes(),
-		ProposalKeyIndex:          uint64(t.ProposalKey.KeyIndex),
-		ProposalKeySequenceNumber: t.ProposalKey.SequenceNumber,
-		Payer:                     t.Payer.Bytes(),
-		Authorizers:               authorizers,
+	buf = rlpAppendBytes(buf, t.ReferenceBlockID[:])
+	buf = rlpAppendUint(buf, t.GasLimit)
+	buf = rlpAppendBytes(buf, t.ProposalKey.Address[:])
+	buf = rlpAppendUint(buf, uint64(t.ProposalKey.KeyIndex))
+	buf = rlpAppendUint(buf, t.ProposalKey.SequenceNumber)
+	buf = rlpAppendBytes(buf, t.Payer[:])
+
+	buf = rlpAppendListHeader(buf, len(t.Authorizers)*rlpBytesSize(EmptyAddress[:]))
+	for i := range t.Authorizers {
+		buf = rlpAppendBytes(buf, t.Authorizers[i][:])
 	}
+
+	return buf
 }
 
 // EnvelopeMessage returns the signable message for the transaction envelope.
 //
 // This message is only signed by the payer account.
 func (t *Transaction) EnvelopeMessage() []byte {
-	temp := t.envelopeCanonicalForm()
-	return mustRLPEncode(&temp)
-}
+	contentSize := rlpListSize(t.payloadContentSize()) +
+		rlpListSize(signaturesContentSize(t.PayloadSignatures))
 
-func (t *Transaction) envelopeCanonicalForm() envelopeCanonicalForm {
-	return envelopeCanonicalForm{
-		Payload:           t.payloadCanonicalForm(),
-		PayloadSignatures: signaturesList(t.PayloadSignatures).canonicalForm(),
-	}
+	buf := make([]byte, 0, rlpListSize(contentSize))
+	buf = rlpAppendListHeader(buf, contentSize)
+	buf = t.appendPayload(buf)
+	return appendSignatures(buf, t.PayloadSignatures)
 }
 
 // Encode serializes the full transaction data including the payload and all signatures.
 func (t *Transaction) Encode() []byte {
-	temp := struct {
-		Payload            payloadCanonicalForm
-		PayloadSignatures  interface{}
-		EnvelopeSignatures interface{}
-	}{
-		Payload:            t.payloadCanonicalForm(),
-		PayloadSignatures:  signaturesList(t.PayloadSignatures).canonicalForm(),
-		EnvelopeSignatures: signaturesList(t.EnvelopeSignatures).canonicalForm(),
-	}
+	contentSize := rlpListSize(t.payloadContentSize()) +
+		rlpListSize(signaturesContentSize(t.PayloadSignatures)) +
+		rlpListSize(signaturesContentSize(t.EnvelopeSignatures))
 
-	return mustRLPEncode(&temp)
+	buf := make([]byte, 0, rlpListSize(contentSize))
+	buf = rlpAppendListHeader(buf, contentSize)
+	buf = t.appendPayload(buf)
+	buf = appendSignatures(buf, t.PayloadSignatures)
+	return appendSignatures(buf, t.EnvelopeSignatures)
 }
 
 // DecodeTransaction decodes the input bytes into a Transaction struct
 // able to decode outputs from PayloadMessage(), EnvelopeMessage() and Encode()
-// functions
+// functions.
+//
+// If the input is malformed, the returned error is a *DecodeError reporting
+// the offset and field at which decoding failed.
 func DecodeTransaction(transactionMessage []byte) (*Transaction, error) {
-	temp, err := decodeTransaction(transactionMessage)
+	d := newRLPDecoder(transactionMessage)
+
+	tx, err := decodeTransaction(d)
 	if err != nil {
 		return nil, err
 	}
 
-	authorizers := make([]Address, len(temp.Payload.Authorizers))
-	for i, auth := range temp.Payload.Authorizers {
-		authorizers[i] = BytesToAddress(auth)
-	}
-	t := &Transaction{
-		Script:           temp.Payload.Script,
-		Arguments:        temp.Payload.Arguments,
-		ReferenceBlockID: BytesToID(temp.Payload.ReferenceBlockID),
-		GasLimit:         temp.Payload.GasLimit,
-		ProposalKey: ProposalKey{
-			Address:        BytesToAddress(temp.Payload.ProposalKeyAddress),
-			KeyIndex:       int(temp.Payload.ProposalKeyIndex),
-			SequenceNumber: temp.Payload.ProposalKeySequenceNumber,
-		},
-		Payer:       BytesToAddress(temp.Payload.Payer),
-		Authorizers: authorizers,
-	}
-	signers := t.signerList()
-	if len(temp.PayloadSignatures) > 0 {
-		payloadSignatures := make([]TransactionSignature, len(temp.PayloadSignatures))
-		for i, sig := range temp.PayloadSignatures {
-			payloadSignatures[i] = transactionSignatureFromCanonicalForm(sig)
-			payloadSignatures[i].Address = signers[payloadSignatures[i].SignerIndex]
-		}
-		t.PayloadSignatures = payloadSignatures
-	}
-
-	if len(temp.EnvelopeSignatures) > 0 {
-		envelopeSignatures := make([]TransactionSignature, len(temp.EnvelopeSignatures))
-		for i, sig := range temp.EnvelopeSignatures {
-			envelopeSignatures[i] = transactionSignatureFromCanonicalForm(sig)
-			envelopeSignatures[i].Address = signers[envelopeSignatures[i].SignerIndex]
-		}
-		t.EnvelopeSignatures = envelopeSignatures
+	err = d.finish()
+	if err != nil {
+		return nil, err
 	}
 
-	if len(t.Arguments) == 0 {
-		t.Arguments = nil
+	if len(tx.Arguments) == 0 {
+		tx.Arguments = nil
 	}
-	if len(t.Script) == 0 {
-		t.Script = nil
+	if len(tx.Script) == 0 {
+		tx.Script = nil
 	}
-	return t, nil
+	return tx, nil
 }
 
-func decodeTransaction(transactionMessage []byte) (*transactionCanonicalForm, error) {
-	s := rlp.NewStream(bytes.NewReader(transactionMessage), 0)
-	temp := &transactionCanonicalForm{}
+func decodeTransaction(d *rlpDecoder) (*Transaction, error) {
+	outer, err := d.listDecoder()
+	if err != nil {
+		return nil, withField(err, "transaction")
+	}
 
-	kind, _, err := s.Kind()
+	// Need to look at the type of the first element to determine if how we're going to be decoding
+	isList, err := outer.isList()
 	if err != nil {
-		return nil, err
+		return nil, withField(err, "transaction")
 	}
 
-	// First kind should always be a list
-	if kind != rlp.List {
-		return nil, errors.New("unexpected rlp decoding type")
+	// If first element is not a list, this is just an encoded payload
+	if !isList {
+		tx, err := decodePayload(outer)
+		if err != nil {
+			return nil, err
+		}
+
+		return tx, nil
+	}
+
+	// Otherwise this is either an encoded envelope or a full transaction
+	payload, err := outer.listDecoder()
+	if err != nil {
+		return nil, withField(err, "payload")
 	}
 
-	_, err = s.List()
+	tx, err := decodePayload(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	// Need to look at the type of the first element to determine if how we're going to be decoding
-	kind, _, err = s.Kind()
+	signers := tx.signerList()
+
+	tx.PayloadSignatures, err = decodeSignatures(outer, signers, "payloadSignatures")
 	if err != nil {
 		return nil, err
 	}
-	// If first kind is not list, safe to assume this is actually just encoded payload, and decrypt as such
-	if kind != rlp.List {
-		s.Reset(bytes.NewReader(transactionMessage), 0)
-		txPayload := payloadCanonicalForm{}
-		err := s.Decode(&txPayload)
+
+	// It's possible for the envelope signatures to not exist (e.g. an encoded envelope).
+	if outer.more() {
+		tx.EnvelopeSignatures, err = decodeSignatures(outer, signers, "envelopeSignatures")
 		if err != nil {
 			return nil, err
 		}
-		temp.Payload = txPayload
-		return temp, nil
 	}
 
-	// If we're here, we will assume that we're decoding either a envelopeCanonicalForm
-	// or a full transactionCanonicalForm
+	err = outer.finish()
+	if err != nil {
+		return nil, withField(err, "transaction")
+	}
+
+	return tx, nil
+}
+
+func decodePayload(d *rlpDecoder) (*Transaction, error) {
+	var err error
+	tx := &Transaction{}
 
-	// Decode the payload
-	txPayload := payloadCanonicalForm{}
-	err = s.Decode(&txPayload)
+	tx.Script, err = d.copyBytes()
 	if err != nil {
-		return nil, err
+		return nil, withField(err, "payload.script")
 	}
-	temp.Payload = txPayload
 
-	// Decode the payload sigs
-	payloadSigs := []transactionSignatureCanonicalForm{}
-	err = s.Decode(&payloadSigs)
+	arguments, err := d.listDecoder()
 	if err != nil {
-		return nil, err
+		return nil, withField(err, "payload.arguments")
 	}
-	temp.PayloadSignatures = payloadSigs
 
-	// It's possible for the envelope signature to not exist (e.g. envelopeCanonicalForm).
-	kind, _, err = s.Kind()
-	if errors.Is(err, rlp.EOL) {
-		return temp, nil
-	} else if err != nil {
-		return nil, err
+	for i := 0; arguments.more(); i++ {
+		arg, err := arguments.copyBytes()
+		if err != nil {
+			return nil, withField(err, fmt.Sprintf("payload.arguments[%d]", i))
+		}
+		tx.Arguments = append(tx.Arguments, arg)
 	}
-	// If we're not at EOL, and no error, finish decoding
-	envelopeSigs := []transactionSignatureCanonicalForm{}
-	err = s.Decode(&envelopeSigs)
+
+	referenceBlockID, err := d.bytes()
 	if err != nil {
-		return nil, err
+		return nil, withField(err, "payload.referenceBlockId")
+	}
+	tx.ReferenceBlockID = BytesToID(referenceBlockID)
+
+	tx.GasLimit, err = d.uint()
+	if err != nil {
+		return nil, withField(err, "payload.gasLimit")
+	}
+
+	proposalKeyAddress, err := d.bytes()
+	if err != nil {
+		return nil, withField(err, "payload.proposalKey.address")
+	}
+	tx.ProposalKey.Address = BytesToAddress(proposalKeyAddress)
+
+	proposalKeyIndex, err := d.uint()
+	if err != nil {
+		return nil, withField(err, "payload.proposalKey.keyIndex")
+	}
+	tx.ProposalKey.KeyIndex = int(proposalKeyIndex)
+
+	tx.ProposalKey.SequenceNumber, err = d.uint()
+	if err != nil {
+		return nil, withField(err, "payload.proposalKey.sequenceNumber")
+	}
+
+	payer, err := d.bytes()
+	if err != nil {
+		return nil, withField(err, "payload.payer")
+	}
+	tx.Payer = BytesToAddress(payer)
+
+	authorizers, err := d.listDecoder()
+	if err != nil {
+		return nil, withField(err, "payload.authorizers")
 	}
-	temp.EnvelopeSignatures = envelopeSigs
 
-	return temp, nil
+	tx.Authorizers = make([]Address, 0)
+	for i := 0; authorizers.more(); i++ {
+		authorizer, err := authorizers.bytes()
+		if err != nil {
+			return nil, withField(err, fmt.Sprintf("payload.authorizers[%d]", i))
+		}
+		tx.Authorizers = append(tx.Authorizers, BytesToAddress(authorizer))
+	}
+
+	err = d.finish()
+	if err != nil {
+		return nil, withField(err, "payload")
+	}
+
+	return tx, nil
+}
+
+func decodeSignatures(d *rlpDecoder, signers []Address, field string) ([]TransactionSignature, error) {
+	list, err := d.listDecoder()
+	if err != nil {
+		return nil, withField(err, field)
+	}
+
+	var signatures []TransactionSignature
+	for i := 0; list.more(); i++ {
+		sig, err := decodeSignature(list, signers)
+		if err != nil {
+			return nil, withField(err, fmt.Sprintf("%s[%d]", field, i))
+		}
+		signatures = append(signatures, sig)
+	}
+
+	return signatures, nil
+}
+
+func decodeSignature(d *rlpDecoder, signers []Address) (TransactionSignature, error) {
+	var sig TransactionSignature
+
+	list, err := d.listDecoder()
+	if err != nil {
+		return sig, err
+	}
+
+	offset := list.pos
+	sig.SignerIndex, err = list.int()
+	if err != nil {
+		return sig, withField(err, "signerIndex")
+	}
+	if sig.SignerIndex >= len(signers) {
+		return sig, &DecodeError{Offset: offset, Field: "signerIndex", Err: errRLPSignerOutOfRange}
+	}
+	sig.Address = signers[sig.SignerIndex]
+
+	keyIndex, err := list.uint()
+	if err != nil {
+		return sig, withField(err, "keyIndex")
+	}
+	sig.KeyIndex = int(keyIndex)
+
+	sig.Signature, err = list.copyBytes()
+	if err != nil {
+		return sig, withField(err, "signature")
+	}
+
+	err = list.finish()
+	if err != nil {
+		return sig, err
+	}
+
+	return sig, nil
 }
 
 // A ProposalKey is the key that specifies the proposal key and sequence number for a transaction.
@@ -561,28 +739,6 @@ type TransactionSignature struct {
 	Signature   []byte
 }
 
-type transactionSignatureCanonicalForm struct {
-	SignerIndex uint
-	KeyIndex    uint
-	Signature   []byte
-}
-
-func (s TransactionSignature) canonicalForm() transactionSignatureCanonicalForm {
-	return transactionSignatureCanonicalForm{
-		SignerIndex: uint(s.SignerIndex), // int is not RLP-serializable
-		KeyIndex:    uint(s.KeyIndex),    // int is not RLP-serializable
-		Signature:   s.Signature,
-	}
-}
-
-func transactionSignatureFromCanonicalForm(v transactionSignatureCanonicalForm) TransactionSignature {
-	return TransactionSignature{
-		SignerIndex: int(v.SignerIndex),
-		KeyIndex:    int(v.KeyIndex),
-		Signature:   v.Signature,
-	}
-}
-
 func compareSignatures(signatures []TransactionSignature) func(i, j int) bool {
 	return func(i, j int) bool {
 		sigA := signatures[i]
@@ -596,16 +752,32 @@ func compareSignatures(signatures []TransactionSignature) func(i, j int) bool {
 	}
 }
 
-type signaturesList []TransactionSignature
+// signaturesContentSize returns the size of the encoded signatures, without the list header.
+func signaturesContentSize(signatures []TransactionSignature) int {
+	size := 0
+	for _, sig := range signatures {
+		size += rlpListSize(signatureContentSize(sig))
+	}
 
-func (s signaturesList) canonicalForm() []transactionSignatureCanonicalForm {
-	signatures := make([]transactionSignatureCanonicalForm, len(s))
+	return size
+}
+
+func signatureContentSize(sig TransactionSignature) int {
+	return rlpUintSize(uint64(sig.SignerIndex)) +
+		rlpUintSize(uint64(sig.KeyIndex)) +
+		rlpBytesSize(sig.Signature)
+}
 
-	for i, signature := range s {
-		signatures[i] = signature.canonicalForm()
+func appendSignatures(buf []byte, signatures []TransactionSignature) []byte {
+	buf = rlpAppendListHeader(buf, signaturesContentSize(signatures))
+	for _, sig := range signatures {
+		buf = rlpAppendListHeader(buf, signatureContentSize(sig))
+		buf = rlpAppendUint(buf, uint64(sig.SignerIndex))
+		buf = rlpAppendUint(buf, uint64(sig.KeyIndex))
+		buf = rlpAppendBytes(buf, sig.Signature)
 	}
 
-	return signatures
+	return buf
 }
 
 type TransactionResult struct {
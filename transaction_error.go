@@ -0,0 +1,299 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// An ErrorCode is a code reported by Flow for a failed transaction.
+//
+// Codes in the range 1000-1999 are errors caused by the transaction, codes
+// in the range 2000-2999 are failures of the execution environment.
+type ErrorCode int
+
+// List of documented Flow error codes.
+const (
+	// transaction validation errors
+	ErrorCodeTxValidation                 ErrorCode = 1000
+	ErrorCodeInvalidTxByteSize            ErrorCode = 1001
+	ErrorCodeInvalidReferenceBlock        ErrorCode = 1002
+	ErrorCodeExpiredTransaction           ErrorCode = 1003
+	ErrorCodeInvalidScript                ErrorCode = 1004
+	ErrorCodeInvalidGasLimit              ErrorCode = 1005
+	ErrorCodeInvalidProposalSignature     ErrorCode = 1006
+	ErrorCodeInvalidProposalSeqNumber     ErrorCode = 1007
+	ErrorCodeInvalidPayloadSignature      ErrorCode = 1008
+	ErrorCodeInvalidEnvelopeSignature     ErrorCode = 1009
+	ErrorCodeFVMInternal                  ErrorCode = 1050
+	ErrorCodeValue                        ErrorCode = 1051
+	ErrorCodeInvalidArgument              ErrorCode = 1052
+	ErrorCodeInvalidAddress               ErrorCode = 1053
+	ErrorCodeInvalidLocation              ErrorCode = 1054
+	ErrorCodeAccountAuthorization         ErrorCode = 1055
+	ErrorCodeOperationAuthorization       ErrorCode = 1056
+	ErrorCodeOperationNotSupported        ErrorCode = 1057
+	ErrorCodeBlockHeightOutOfRange        ErrorCode = 1058
+	ErrorCodeExecution                    ErrorCode = 1100
+	ErrorCodeCadenceRuntime               ErrorCode = 1101
+	ErrorCodeEncodingUnsupportedValue     ErrorCode = 1102
+	ErrorCodeStorageCapacityExceeded      ErrorCode = 1103
+	ErrorCodeGasLimitExceeded             ErrorCode = 1104
+	ErrorCodeEventLimitExceeded           ErrorCode = 1105
+	ErrorCodeLedgerInteractionLimit       ErrorCode = 1106
+	ErrorCodeStateKeySizeLimit            ErrorCode = 1107
+	ErrorCodeStateValueSizeLimit          ErrorCode = 1108
+	ErrorCodeTransactionFeeDeduction      ErrorCode = 1109
+	ErrorCodeComputationLimitExceeded     ErrorCode = 1110
+	ErrorCodeMemoryLimitExceeded          ErrorCode = 1111
+	ErrorCodeExecutionParameterDecoding   ErrorCode = 1112
+	ErrorCodeScriptExecutionTimedOut      ErrorCode = 1113
+	ErrorCodeScriptExecutionCancelled     ErrorCode = 1114
+	ErrorCodeEventEncoding                ErrorCode = 1115
+	ErrorCodeInvalidInternalStateAccess   ErrorCode = 1116
+	ErrorCodeInsufficientPayerBalance     ErrorCode = 1118
+	ErrorCodeAccount                      ErrorCode = 1200
+	ErrorCodeAccountNotFound              ErrorCode = 1201
+	ErrorCodeAccountPublicKeyNotFound     ErrorCode = 1202
+	ErrorCodeAccountAlreadyExists         ErrorCode = 1203
+	ErrorCodeFrozenAccount                ErrorCode = 1204
+	ErrorCodeAccountStorageNotInitialized ErrorCode = 1205
+	ErrorCodeAccountPublicKeyLimit        ErrorCode = 1206
+	ErrorCodeContract                     ErrorCode = 1250
+	ErrorCodeContractNotFound             ErrorCode = 1251
+	ErrorCodeContractNamesNotFound        ErrorCode = 1252
+	ErrorCodeEVM                          ErrorCode = 1300
+
+	// execution environment failures
+	FailureCodeUnknown                   ErrorCode = 2000
+	FailureCodeEncoding                  ErrorCode = 2001
+	FailureCodeLedger                    ErrorCode = 2002
+	FailureCodeStateMerge                ErrorCode = 2003
+	FailureCodeBlockFinder               ErrorCode = 2004
+	FailureCodeHasher                    ErrorCode = 2005
+	FailureCodeParseRestrictedModeAccess ErrorCode = 2006
+	FailureCodePayerBalanceCheck         ErrorCode = 2007
+	FailureCodeDerivedDataCache          ErrorCode = 2008
+	FailureCodeRandomSource              ErrorCode = 2009
+	FailureCodeEVM                       ErrorCode = 2010
+)
+
+// Category returns the category of the error code.
+func (c ErrorCode) Category() ErrorCategory {
+	switch {
+	case c == ErrorCodeCadenceRuntime:
+		return ErrorCategoryCadenceRuntime
+	case c >= 1000 && c < 2000:
+		return ErrorCategoryUser
+	case c >= 2000 && c < 3000:
+		return ErrorCategoryFailure
+	default:
+		return ErrorCategoryUnknown
+	}
+}
+
+// An ErrorCategory classifies Flow error codes.
+type ErrorCategory int
+
+const (
+	// ErrorCategoryUnknown is the category of errors without a known code.
+	ErrorCategoryUnknown ErrorCategory = iota
+	// ErrorCategoryUser is the category of errors caused by the transaction, such as exceeding limits.
+	ErrorCategoryUser
+	// ErrorCategoryCadenceRuntime is the category of errors raised by the Cadence runtime, such as failed conditions.
+	ErrorCategoryCadenceRuntime
+	// ErrorCategoryFailure is the category of failures of the execution environment.
+	ErrorCategoryFailure
+)
+
+// String returns the string representation of the error category.
+func (c ErrorCategory) String() string {
+	switch c {
+	case ErrorCategoryUser:
+		return "USER"
+	case ErrorCategoryCadenceRuntime:
+		return "CADENCE_RUNTIME"
+	case ErrorCategoryFailure:
+		return "FAILURE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// A CadenceError is an error reported by the Cadence runtime, e.g. a failed pre-condition.
+type CadenceError struct {
+	// Message is the error message, e.g. "pre-condition failed: Amount withdrawn must be less than or equal than the balance of the Vault".
+	Message string
+	// Location is the program location of the error, e.g. "1654653399040a61.FlowToken".
+	Location string
+	// Line is the line of the error in the program.
+	Line int
+	// Column is the column of the error in the program.
+	Column int
+}
+
+// A TransactionExecutionError is the error of a transaction that failed to execute.
+//
+// Flow reports execution errors as messages with nested causes, each of which is prefixed with
+// its error code, e.g. "[Error Code: 1101] error caused by: ... [Error Code: 1101] cadence runtime error: ...".
+// The outermost error is returned, and the nested errors are available through Cause.
+type TransactionExecutionError struct {
+	// Code is the Flow error code, or zero if the message has no error code.
+	Code ErrorCode
+	// Message is the message of this error, excluding nested causes.
+	Message string
+	// Cadence is the Cadence runtime error, if this is a Cadence runtime error.
+	Cadence *CadenceError
+	// Cause is the nested error, if any.
+	Cause *TransactionExecutionError
+
+	raw string
+}
+
+var (
+	errorCodePattern       = regexp.MustCompile(`\[Error Code: (\d+)\]`)
+	cadenceErrorPattern    = regexp.MustCompile(`(?m)^\s*error: (.*)$`)
+	cadenceLocationPattern = regexp.MustCompile(`-->\s*(\S+):(\d+):(\d+)`)
+)
+
+// ParseTransactionExecutionError parses the error message of a failed transaction.
+func ParseTransactionExecutionError(message string) *TransactionExecutionError {
+	matches := errorCodePattern.FindAllStringSubmatchIndex(message, -1)
+	if len(matches) == 0 {
+		return &TransactionExecutionError{
+			Message: strings.TrimSpace(message),
+			raw:     message,
+		}
+	}
+
+	var root, parent *TransactionExecutionError
+	for i, match := range matches {
+		end := len(message)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+
+		code, _ := strconv.Atoi(message[match[2]:match[3]])
+		segment := message[match[1]:end]
+
+		e := &TransactionExecutionError{
+			Code:    ErrorCode(code),
+			Message: strings.TrimSpace(segment),
+			raw:     message[match[0]:],
+		}
+		if e.Code == ErrorCodeCadenceRuntime {
+			e.Cadence = parseCadenceError(segment)
+		}
+
+		if parent == nil {
+			root = e
+			// keep any text preceding the first error code
+			root.raw = message
+		} else {
+			parent.Cause = e
+		}
+		parent = e
+	}
+
+	return root
+}
+
+func parseCadenceError(message string) *CadenceError {
+	match := cadenceErrorPattern.FindStringSubmatchIndex(message)
+	if match == nil {
+		return nil
+	}
+
+	cadenceErr := &CadenceError{
+		Message: strings.TrimSpace(message[match[2]:match[3]]),
+	}
+
+	location := cadenceLocationPattern.FindStringSubmatch(message[match[1]:])
+	if location != nil {
+		cadenceErr.Location = location[1]
+		cadenceErr.Line, _ = strconv.Atoi(location[2])
+		cadenceErr.Column, _ = strconv.Atoi(location[3])
+	}
+
+	return cadenceErr
+}
+
+// Error returns the full error message, including nested causes.
+func (e *TransactionExecutionError) Error() string {
+	return e.raw
+}
+
+// Unwrap returns the nested error, if any.
+func (e *TransactionExecutionError) Unwrap() error {
+	if e.Cause == nil {
+		return nil
+	}
+
+	return e.Cause
+}
+
+// Category returns the category of the error code.
+func (e *TransactionExecutionError) Category() ErrorCategory {
+	return e.Code.Category()
+}
+
+// RootCause returns the innermost nested error.
+func (e *TransactionExecutionError) RootCause() *TransactionExecutionError {
+	for e.Cause != nil {
+		e = e.Cause
+	}
+
+	return e
+}
+
+// HasCode returns true if this error or any of its nested errors has the given code.
+func (e *TransactionExecutionError) HasCode(code ErrorCode) bool {
+	for ; e != nil; e = e.Cause {
+		if e.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CadenceError returns the first Cadence runtime error in the chain of nested errors, if any.
+func (e *TransactionExecutionError) CadenceError() *CadenceError {
+	for ; e != nil; e = e.Cause {
+		if e.Cadence != nil {
+			return e.Cadence
+		}
+	}
+
+	return nil
+}
+
+// HasErrorCode returns true if err is a transaction execution error with the given code,
+// at any level of nesting.
+func HasErrorCode(err error, code ErrorCode) bool {
+	var execErr *TransactionExecutionError
+	if !errors.As(err, &execErr) {
+		return false
+	}
+
+	return execErr.HasCode(code)
+}
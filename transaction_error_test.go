@@ -0,0 +1,111 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+const insufficientBalanceMessage = `[Error Code: 1101] error caused by: 1 error occurred:
+	* transaction execute failed: [Error Code: 1101] cadence runtime error: Execution failed:
+error: pre-condition failed: Amount withdrawn must be less than or equal than the balance of the Vault
+   --> 1654653399040a61.FlowToken:110:16
+    |
+110 |                 amount <= self.balance:
+    |                 ^^^^^^^^^^^^^^^^^^^^^^
+`
+
+func TestParseTransactionExecutionError(t *testing.T) {
+	t.Run("Cadence runtime error", func(t *testing.T) {
+		err := flow.ParseTransactionExecutionError(insufficientBalanceMessage)
+
+		assert.Equal(t, insufficientBalanceMessage, err.Error())
+		assert.Equal(t, flow.ErrorCodeCadenceRuntime, err.Code)
+		assert.Equal(t, flow.ErrorCategoryCadenceRuntime, err.Category())
+
+		require.NotNil(t, err.Cause)
+		assert.Equal(t, flow.ErrorCodeCadenceRuntime, err.Cause.Code)
+		assert.Nil(t, err.Cause.Cause)
+		assert.Same(t, err.Cause, err.RootCause())
+
+		cadenceErr := err.CadenceError()
+		require.NotNil(t, cadenceErr)
+		assert.Equal(t, "pre-condition failed: Amount withdrawn must be less than or equal than the balance of the Vault", cadenceErr.Message)
+		assert.Equal(t, "1654653399040a61.FlowToken", cadenceErr.Location)
+		assert.Equal(t, 110, cadenceErr.Line)
+		assert.Equal(t, 16, cadenceErr.Column)
+	})
+
+	t.Run("Nested error codes", func(t *testing.T) {
+		message := "[Error Code: 1100] execution failed: [Error Code: 1110] computation exceeds limit (9999)"
+		err := flow.ParseTransactionExecutionError(message)
+
+		assert.Equal(t, flow.ErrorCodeExecution, err.Code)
+		assert.Equal(t, "execution failed:", err.Message)
+		assert.Equal(t, flow.ErrorCategoryUser, err.Category())
+
+		root := err.RootCause()
+		assert.Equal(t, flow.ErrorCodeComputationLimitExceeded, root.Code)
+		assert.Equal(t, "computation exceeds limit (9999)", root.Message)
+		assert.Equal(t, "[Error Code: 1110] computation exceeds limit (9999)", root.Error())
+
+		assert.True(t, err.HasCode(flow.ErrorCodeComputationLimitExceeded))
+		assert.False(t, err.HasCode(flow.ErrorCodeInsufficientPayerBalance))
+		assert.Nil(t, err.CadenceError())
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		err := flow.ParseTransactionExecutionError("[Error Code: 2002] ledger failure: failed to read register")
+
+		assert.Equal(t, flow.FailureCodeLedger, err.Code)
+		assert.Equal(t, flow.ErrorCategoryFailure, err.Category())
+	})
+
+	t.Run("Without error code", func(t *testing.T) {
+		err := flow.ParseTransactionExecutionError("transaction execution failed")
+
+		assert.Equal(t, flow.ErrorCode(0), err.Code)
+		assert.Equal(t, flow.ErrorCategoryUnknown, err.Category())
+		assert.Equal(t, "transaction execution failed", err.Message)
+		assert.Equal(t, "transaction execution failed", err.Error())
+		assert.Nil(t, err.Cause)
+		assert.Nil(t, err.Unwrap())
+	})
+}
+
+func TestHasErrorCode(t *testing.T) {
+	err := flow.ParseTransactionExecutionError("[Error Code: 1100] execution failed: [Error Code: 1118] payer has insufficient balance")
+	wrapped := fmt.Errorf("transaction failed: %w", err)
+
+	assert.True(t, flow.HasErrorCode(wrapped, flow.ErrorCodeInsufficientPayerBalance))
+	assert.True(t, flow.HasErrorCode(wrapped, flow.ErrorCodeExecution))
+	assert.False(t, flow.HasErrorCode(wrapped, flow.ErrorCodeComputationLimitExceeded))
+	assert.False(t, flow.HasErrorCode(errors.New("[Error Code: 1118] payer has insufficient balance"), flow.ErrorCodeInsufficientPayerBalance))
+
+	var cause *flow.TransactionExecutionError
+	require.True(t, errors.As(err.Unwrap(), &cause))
+	assert.Equal(t, flow.ErrorCodeInsufficientPayerBalance, cause.Code)
+}
@@ -19,6 +19,7 @@
 package flow_test
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"testing"
@@ -237,6 +238,31 @@ func TestTransaction_SetReferenceBlockID(t *testing.T) {
 	assert.Equal(t, blockID, tx.ReferenceBlockID)
 }
 
+func TestTransaction_ExpiryHeight(t *testing.T) {
+	header := test.BlockHeaderGenerator().New()
+
+	tx := flow.NewTransaction().
+		SetReferenceBlockID(header.ID)
+
+	expiryHeight, err := tx.ExpiryHeight(&header)
+	require.NoError(t, err)
+	assert.Equal(t, header.Height+flow.DefaultTransactionExpiry, expiryHeight)
+
+	expired, err := tx.IsExpired(&header, expiryHeight)
+	require.NoError(t, err)
+	assert.False(t, expired)
+
+	expired, err = tx.IsExpired(&header, expiryHeight+1)
+	require.NoError(t, err)
+	assert.True(t, expired)
+
+	other := header
+	other.ID = flow.HexToID("ff")
+
+	_, err = tx.ExpiryHeight(&other)
+	assert.Error(t, err)
+}
+
 func TestTransaction_SetGasLimit(t *testing.T) {
 	var gasLimit uint64 = 42
 
@@ -692,3 +718,92 @@ func TestTransaction_RLPMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_SignWithContext(t *testing.T) {
+	accounts := newSigningAccounts([]int{1000}, []int{1000})
+	proposer, payer := accounts[0], accounts[1]
+
+	newTransaction := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(proposer.account.Address, 0, 0).
+			SetPayer(payer.account.Address).
+			AddAuthorizer(proposer.account.Address)
+	}
+
+	t.Run("Same signatures as without context", func(t *testing.T) {
+		tx := newTransaction()
+		require.NoError(t, tx.SignPayloadWithContext(context.Background(), proposer.account.Address, 0, proposer.signers[0]))
+		require.NoError(t, tx.SignEnvelopeWithContext(context.Background(), payer.account.Address, 0, payer.signers[0]))
+
+		assert.NoError(t, tx.VerifySignatures(lookupAccounts(proposer, payer)))
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		tx := newTransaction()
+		assert.ErrorIs(t, tx.SignPayloadWithContext(ctx, proposer.account.Address, 0, proposer.signers[0]), context.Canceled)
+		assert.ErrorIs(t, tx.SignEnvelopeWithContext(ctx, payer.account.Address, 0, payer.signers[0]), context.Canceled)
+		assert.Empty(t, tx.PayloadSignatures)
+		assert.Empty(t, tx.EnvelopeSignatures)
+
+		_, err := flow.SignUserMessageWithContext(ctx, proposer.signers[0], []byte("message"))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestTransaction_CanonicalArguments(t *testing.T) {
+	newTransaction := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(flow.HexToID("01")).
+			SetProposalKey(flow.HexToAddress("01"), 0, 0).
+			SetPayer(flow.HexToAddress("01"))
+	}
+
+	value, err := cadence.NewUFix64("1.5")
+	require.NoError(t, err)
+
+	expected := newTransaction()
+	require.NoError(t, expected.AddArgument(value))
+	assert.Equal(t, `{"value":"1.50000000","type":"UFix64"}`, string(expected.Arguments[0]))
+
+	rawArgs := []string{
+		string(jsoncdc.MustEncode(value)),
+		`{"type":"UFix64","value":"1.5"}`,
+		"{ \"value\": \"1.50000000\", \"type\": \"UFix64\" }\n",
+	}
+
+	for _, rawArg := range rawArgs {
+		tx := newTransaction().AddRawArgument([]byte(rawArg))
+		assert.Equal(t, expected.Arguments, tx.Arguments, rawArg)
+		assert.Equal(t, expected.ID(), tx.ID(), rawArg)
+	}
+
+	t.Run("Invalid argument is kept", func(t *testing.T) {
+		tx := newTransaction().AddRawArgument([]byte("foo"))
+		assert.Equal(t, [][]byte{[]byte("foo")}, tx.Arguments)
+		assert.ErrorIs(t, tx.Validate(flow.WithCanonicalArguments()), flow.ErrInvalidArgument)
+	})
+
+	t.Run("Checked argument", func(t *testing.T) {
+		tx := newTransaction()
+		require.NoError(t, tx.AddCheckedRawArgument([]byte(rawArgs[2])))
+		assert.Equal(t, expected.Arguments, tx.Arguments)
+
+		assert.Error(t, tx.AddCheckedRawArgument([]byte("foo")))
+		assert.Equal(t, expected.Arguments, tx.Arguments)
+	})
+
+	t.Run("Canonical form", func(t *testing.T) {
+		arg, err := flow.CanonicalArgument([]byte(rawArgs[1]))
+		require.NoError(t, err)
+		assert.Equal(t, expected.Arguments[0], arg)
+
+		_, err = flow.CanonicalArgument([]byte("foo"))
+		assert.Error(t, err)
+	})
+}
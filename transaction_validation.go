@@ -0,0 +1,220 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultMaxTransactionGasLimit is the maximum gas limit accepted by the network.
+	DefaultMaxTransactionGasLimit = 9999
+
+	// DefaultMaxTransactionByteSize is the maximum encoded size of a transaction accepted by the network.
+	DefaultMaxTransactionByteSize = 1_500_000
+)
+
+var (
+	// ErrEmptyScript is returned when a transaction has no script.
+	ErrEmptyScript = errors.New("transaction script is empty")
+	// ErrInvalidGasLimit is returned when a transaction gas limit is zero or exceeds the maximum.
+	ErrInvalidGasLimit = errors.New("invalid gas limit")
+	// ErrMissingReferenceBlock is returned when a transaction has no reference block.
+	ErrMissingReferenceBlock = errors.New("missing reference block ID")
+	// ErrMissingPayer is returned when a transaction has no payer.
+	ErrMissingPayer = errors.New("missing payer")
+	// ErrMissingProposalKey is returned when a transaction has no proposal key.
+	ErrMissingProposalKey = errors.New("missing proposal key")
+	// ErrInvalidArgument is returned when a transaction argument is not valid JSON-CDC.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrNonCanonicalArgument is returned when a transaction argument is not in canonical JSON-CDC form.
+	ErrNonCanonicalArgument = errors.New("non-canonical argument")
+	// ErrDuplicateAuthorizer is returned when an account is declared as authorizer more than once.
+	ErrDuplicateAuthorizer = errors.New("duplicate authorizer")
+	// ErrUnexpectedSigner is returned when a signature is from an account that is not required to sign.
+	ErrUnexpectedSigner = errors.New("unexpected signer")
+	// ErrMissingPayerSignature is returned when the payer has not signed the envelope.
+	ErrMissingPayerSignature = errors.New("missing payer envelope signature")
+	// ErrTransactionTooLarge is returned when the encoded transaction exceeds the maximum size.
+	ErrTransactionTooLarge = errors.New("transaction too large")
+)
+
+// A TransactionValidationError lists all the problems found when validating a transaction.
+//
+// The individual errors can be matched with errors.Is.
+type TransactionValidationError struct {
+	Errors []error
+}
+
+func (e TransactionValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf("invalid transaction: %s", strings.Join(messages, "; "))
+}
+
+// Is returns true if any of the validation errors matches the target.
+func (e TransactionValidationError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type transactionValidationConfig struct {
+	maxGasLimit        uint64
+	maxByteSize        int
+	canonicalArguments bool
+}
+
+// A TransactionValidationOption configures the limits enforced by Transaction.Validate.
+type TransactionValidationOption func(*transactionValidationConfig)
+
+// WithMaxGasLimit sets the maximum gas limit enforced by Transaction.Validate.
+func WithMaxGasLimit(limit uint64) TransactionValidationOption {
+	return func(c *transactionValidationConfig) {
+		c.maxGasLimit = limit
+	}
+}
+
+// WithMaxTransactionByteSize sets the maximum encoded transaction size enforced by Transaction.Validate.
+func WithMaxTransactionByteSize(size int) TransactionValidationOption {
+	return func(c *transactionValidationConfig) {
+		c.maxByteSize = size
+	}
+}
+
+// WithCanonicalArguments makes Transaction.Validate reject arguments that are not in the
+// canonical form returned by CanonicalArgument.
+//
+// Arguments added with AddArgument and AddCheckedRawArgument are always canonical, so this is
+// mostly useful to check transactions received from other parties. Arguments that are not valid
+// JSON-CDC, which AddRawArgument keeps unchanged, are reported as ErrInvalidArgument with or
+// without this option.
+func WithCanonicalArguments() TransactionValidationOption {
+	return func(c *transactionValidationConfig) {
+		c.canonicalArguments = true
+	}
+}
+
+// Validate performs pre-flight checks on this transaction and returns a
+// TransactionValidationError listing every problem found.
+//
+// A transaction is invalid if:
+// - It has an empty script
+// - Its gas limit is zero or exceeds the maximum gas limit
+// - It has no reference block, payer or proposal key
+// - An argument is not valid JSON-CDC, or not canonical if WithCanonicalArguments is given
+// - An account is declared as authorizer more than once
+// - A signature is from an account that is not a proposer, payer or authorizer
+// - The payer has not signed the envelope
+// - Its encoded size exceeds the maximum transaction size
+func (t *Transaction) Validate(opts ...TransactionValidationOption) error {
+	config := transactionValidationConfig{
+		maxGasLimit: DefaultMaxTransactionGasLimit,
+		maxByteSize: DefaultMaxTransactionByteSize,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var errs []error
+
+	if len(t.Script) == 0 {
+		errs = append(errs, ErrEmptyScript)
+	}
+
+	if t.GasLimit == 0 {
+		errs = append(errs, fmt.Errorf("%w: gas limit must be greater than zero", ErrInvalidGasLimit))
+	} else if t.GasLimit > config.maxGasLimit {
+		errs = append(errs, fmt.Errorf("%w: %d exceeds the maximum of %d", ErrInvalidGasLimit, t.GasLimit, config.maxGasLimit))
+	}
+
+	if t.ReferenceBlockID == EmptyID {
+		errs = append(errs, ErrMissingReferenceBlock)
+	}
+
+	if t.Payer == EmptyAddress {
+		errs = append(errs, ErrMissingPayer)
+	}
+
+	if t.ProposalKey.Address == EmptyAddress {
+		errs = append(errs, ErrMissingProposalKey)
+	}
+
+	for i, arg := range t.Arguments {
+		canonicalArg, err := CanonicalArgument(arg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w at index %d: %s", ErrInvalidArgument, i, err))
+			continue
+		}
+
+		if config.canonicalArguments && !bytes.Equal(arg, canonicalArg) {
+			errs = append(errs, fmt.Errorf("%w at index %d", ErrNonCanonicalArgument, i))
+		}
+	}
+
+	seenAuthorizers := make(map[Address]struct{}, len(t.Authorizers))
+	for _, authorizer := range t.Authorizers {
+		if _, ok := seenAuthorizers[authorizer]; ok {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrDuplicateAuthorizer, authorizer))
+			continue
+		}
+		seenAuthorizers[authorizer] = struct{}{}
+	}
+
+	signers := t.signerMap()
+	for _, sig := range t.PayloadSignatures {
+		if _, ok := signers[sig.Address]; !ok {
+			errs = append(errs, fmt.Errorf("%w: payload signature from %s", ErrUnexpectedSigner, sig.Address))
+		}
+	}
+
+	payerSigned := false
+	for _, sig := range t.EnvelopeSignatures {
+		if _, ok := signers[sig.Address]; !ok {
+			errs = append(errs, fmt.Errorf("%w: envelope signature from %s", ErrUnexpectedSigner, sig.Address))
+		}
+		if sig.Address == t.Payer {
+			payerSigned = true
+		}
+	}
+
+	if t.Payer != EmptyAddress && !payerSigned {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrMissingPayerSignature, t.Payer))
+	}
+
+	if size := len(t.Encode()); size > config.maxByteSize {
+		errs = append(errs, fmt.Errorf("%w: %d bytes exceeds the maximum of %d", ErrTransactionTooLarge, size, config.maxByteSize))
+	}
+
+	if len(errs) > 0 {
+		return TransactionValidationError{Errors: errs}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func validTransaction() *flow.Transaction {
+	addresses := test.AddressGenerator()
+	proposer := addresses.New()
+	payer := addresses.New()
+
+	tx := flow.NewTransaction().
+		SetScript(test.GreetingScript).
+		SetReferenceBlockID(test.IdentifierGenerator().New()).
+		SetProposalKey(proposer, 0, 42).
+		SetPayer(payer).
+		AddAuthorizer(proposer)
+
+	_ = tx.AddArgument(cadence.String("hello"))
+
+	tx.AddPayloadSignature(proposer, 0, []byte{1})
+	tx.AddEnvelopeSignature(payer, 0, []byte{2})
+
+	return tx
+}
+
+func TestTransaction_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, validTransaction().Validate())
+	})
+
+	t.Run("Empty transaction", func(t *testing.T) {
+		err := (&flow.Transaction{}).Validate()
+
+		var validationErr flow.TransactionValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Errors, 5)
+
+		assert.ErrorIs(t, err, flow.ErrEmptyScript)
+		assert.ErrorIs(t, err, flow.ErrInvalidGasLimit)
+		assert.ErrorIs(t, err, flow.ErrMissingReferenceBlock)
+		assert.ErrorIs(t, err, flow.ErrMissingPayer)
+		assert.ErrorIs(t, err, flow.ErrMissingProposalKey)
+	})
+
+	t.Run("Gas limit above maximum", func(t *testing.T) {
+		tx := validTransaction().SetGasLimit(flow.DefaultMaxTransactionGasLimit + 1)
+		assert.ErrorIs(t, tx.Validate(), flow.ErrInvalidGasLimit)
+
+		tx.SetGasLimit(100)
+		assert.ErrorIs(t, tx.Validate(flow.WithMaxGasLimit(50)), flow.ErrInvalidGasLimit)
+	})
+
+	t.Run("Invalid argument", func(t *testing.T) {
+		tx := validTransaction().AddRawArgument([]byte(`{"type":"Foo"}`))
+		assert.ErrorIs(t, tx.Validate(), flow.ErrInvalidArgument)
+	})
+
+	t.Run("Non-canonical argument", func(t *testing.T) {
+		tx := validTransaction()
+		tx.Arguments[0] = []byte(`{"type":"String","value":"hello"}` + "\n")
+
+		assert.NoError(t, tx.Validate())
+		assert.ErrorIs(t, tx.Validate(flow.WithCanonicalArguments()), flow.ErrNonCanonicalArgument)
+
+		tx.Arguments[0] = []byte(`{"value":"hello","type":"String"}`)
+		assert.NoError(t, tx.Validate(flow.WithCanonicalArguments()))
+	})
+
+	t.Run("Duplicate authorizer", func(t *testing.T) {
+		tx := validTransaction()
+		tx.AddAuthorizer(tx.Authorizers[0])
+		assert.ErrorIs(t, tx.Validate(), flow.ErrDuplicateAuthorizer)
+	})
+
+	t.Run("Unexpected signer", func(t *testing.T) {
+		tx := validTransaction()
+		tx.AddPayloadSignature(flow.HexToAddress("01"), 0, []byte{3})
+		assert.ErrorIs(t, tx.Validate(), flow.ErrUnexpectedSigner)
+	})
+
+	t.Run("Missing payer signature", func(t *testing.T) {
+		tx := validTransaction()
+		tx.EnvelopeSignatures = nil
+		assert.ErrorIs(t, tx.Validate(), flow.ErrMissingPayerSignature)
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		tx := validTransaction()
+		assert.ErrorIs(t, tx.Validate(flow.WithMaxTransactionByteSize(10)), flow.ErrTransactionTooLarge)
+	})
+}
@@ -0,0 +1,226 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+var (
+	// ErrInvalidSignature is returned when a signature does not verify against its account key.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInsufficientKeyWeight is returned when the signatures of an account do not reach AccountKeyWeightThreshold.
+	ErrInsufficientKeyWeight = errors.New("insufficient key weight")
+	// ErrMissingProposalKeySignature is returned when the proposal key has not signed the transaction.
+	ErrMissingProposalKeySignature = errors.New("missing proposal key signature")
+)
+
+// An AccountLookup resolves the account of a transaction signer, including its keys.
+//
+// For example, a lookup can be implemented with access.Client.GetAccountAtLatestBlock.
+type AccountLookup func(address Address) (*Account, error)
+
+// VerifySignatures verifies every signature of this transaction against the keys of the
+// signing accounts, and checks that every signing role is fully authorized.
+//
+// Payload signatures are verified against PayloadMessage and envelope signatures against
+// EnvelopeMessage, both prefixed with TransactionDomainTag and hashed with the hash algorithm
+// of the signing key. Signatures from revoked keys are rejected, as are multiple signatures from
+// the same key, whether in the payload or the envelope.
+//
+// The proposal key must have signed the transaction, and the valid signatures of the proposer,
+// the payer and each authorizer must reach AccountKeyWeightThreshold. Accounts that are also
+// the payer only need to sign the envelope.
+func (t *Transaction) VerifySignatures(lookup AccountLookup) error {
+	return t.verifySignatures(lookup, true)
+}
+
+// VerifyPayloadSignatures verifies the signatures present on this transaction and checks that
+// every role other than the payer is fully authorized.
+//
+// This is the check a payer should perform before adding its envelope signature.
+// See VerifySignatures for details.
+func (t *Transaction) VerifyPayloadSignatures(lookup AccountLookup) error {
+	return t.verifySignatures(lookup, false)
+}
+
+func (t *Transaction) verifySignatures(lookup AccountLookup, requireEnvelope bool) error {
+	accounts := make(map[Address]*Account)
+	getAccount := func(address Address) (*Account, error) {
+		if account, ok := accounts[address]; ok {
+			return account, nil
+		}
+
+		account, err := lookup(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up account %s: %w", address, err)
+		}
+
+		accounts[address] = account
+		return account, nil
+	}
+
+	// keys that have signed, across both signature lists
+	signed := make(map[Address]map[int]bool)
+
+	payloadWeights, proposalKeyInPayload, err := t.verifySignatureList(
+		t.PayloadSignatures,
+		t.PayloadMessage(),
+		getAccount,
+		signed,
+	)
+	if err != nil {
+		return fmt.Errorf("payload %w", err)
+	}
+
+	envelopeWeights, proposalKeyInEnvelope, err := t.verifySignatureList(
+		t.EnvelopeSignatures,
+		t.EnvelopeMessage(),
+		getAccount,
+		signed,
+	)
+	if err != nil {
+		return fmt.Errorf("envelope %w", err)
+	}
+
+	proposer := t.ProposalKey.Address
+
+	if proposer != t.Payer || requireEnvelope {
+		if !proposalKeyInPayload && !proposalKeyInEnvelope {
+			return fmt.Errorf("%w: key %d of %s", ErrMissingProposalKeySignature, t.ProposalKey.KeyIndex, proposer)
+		}
+	}
+
+	if proposer != t.Payer && payloadWeights[proposer] < AccountKeyWeightThreshold {
+		return newInsufficientKeyWeightError("proposer", proposer, payloadWeights[proposer])
+	}
+
+	for _, authorizer := range t.Authorizers {
+		// an account that is also the payer is only required to sign the envelope
+		if authorizer == t.Payer {
+			continue
+		}
+
+		if payloadWeights[authorizer] < AccountKeyWeightThreshold {
+			return newInsufficientKeyWeightError("authorizer", authorizer, payloadWeights[authorizer])
+		}
+	}
+
+	if requireEnvelope && envelopeWeights[t.Payer] < AccountKeyWeightThreshold {
+		return newInsufficientKeyWeightError("payer", t.Payer, envelopeWeights[t.Payer])
+	}
+
+	return nil
+}
+
+func newInsufficientKeyWeightError(role string, address Address, weight int) error {
+	return fmt.Errorf(
+		"%w: %s %s has signed with a total weight of %d, at least %d is required",
+		ErrInsufficientKeyWeight,
+		role,
+		address,
+		weight,
+		AccountKeyWeightThreshold,
+	)
+}
+
+// verifySignatureList verifies a list of transaction signatures against the given message.
+//
+// It returns the total weight of the valid signatures per account and whether the proposal key
+// is one of the signers. Keys are recorded in signed, and a key that has already signed is
+// rejected.
+func (t *Transaction) verifySignatureList(
+	signatures []TransactionSignature,
+	message []byte,
+	getAccount func(Address) (*Account, error),
+	signed map[Address]map[int]bool,
+) (map[Address]int, bool, error) {
+	message = append(TransactionDomainTag[:], message...)
+
+	weights := make(map[Address]int)
+	proposalKeySigned := false
+
+	for _, sig := range signatures {
+		if signed[sig.Address][sig.KeyIndex] {
+			return nil, false, fmt.Errorf("%w: key %d of %s signed more than once", ErrInvalidSignature, sig.KeyIndex, sig.Address)
+		}
+
+		account, err := getAccount(sig.Address)
+		if err != nil {
+			return nil, false, err
+		}
+
+		key, err := findAccountKey(account, sig.KeyIndex)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w from key %d of %s: %s", ErrInvalidSignature, sig.KeyIndex, sig.Address, err)
+		}
+
+		valid, err := verifyAccountKeySignature(key, sig.Signature, message)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w from key %d of %s: %s", ErrInvalidSignature, sig.KeyIndex, sig.Address, err)
+		}
+		if !valid {
+			return nil, false, fmt.Errorf("%w from key %d of %s", ErrInvalidSignature, sig.KeyIndex, sig.Address)
+		}
+
+		if signed[sig.Address] == nil {
+			signed[sig.Address] = make(map[int]bool)
+		}
+		signed[sig.Address][sig.KeyIndex] = true
+
+		weights[sig.Address] += key.Weight
+
+		if sig.Address == t.ProposalKey.Address && sig.KeyIndex == t.ProposalKey.KeyIndex {
+			proposalKeySigned = true
+		}
+	}
+
+	return weights, proposalKeySigned, nil
+}
+
+// findAccountKey returns the non-revoked key with the given index.
+func findAccountKey(account *Account, keyIndex int) (*AccountKey, error) {
+	for _, key := range account.Keys {
+		if key.Index != keyIndex {
+			continue
+		}
+
+		if key.Revoked {
+			return nil, fmt.Errorf("key %d is revoked", keyIndex)
+		}
+
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("key %d does not exist", keyIndex)
+}
+
+// verifyAccountKeySignature verifies a signature of the domain-tagged message using the
+// public key and hash algorithm of an account key.
+func verifyAccountKeySignature(key *AccountKey, signature []byte, message []byte) (bool, error) {
+	hasher, err := crypto.NewHasher(key.HashAlgo)
+	if err != nil {
+		return false, err
+	}
+
+	return key.PublicKey.Verify(signature, message, hasher)
+}
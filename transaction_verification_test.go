@@ -0,0 +1,196 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type signingAccount struct {
+	account *flow.Account
+	signers []crypto.Signer
+}
+
+// newSigningAccounts creates accounts whose keys each have the given weights.
+func newSigningAccounts(weights ...[]int) []*signingAccount {
+	addresses := test.AddressGenerator()
+	keys := test.AccountKeyGenerator()
+
+	accounts := make([]*signingAccount, len(weights))
+	for i, accountWeights := range weights {
+		a := &signingAccount{
+			account: &flow.Account{Address: addresses.New()},
+		}
+
+		for j, weight := range accountWeights {
+			key, signer := keys.NewWithSigner()
+			key.Index = j
+			key.Weight = weight
+			a.account.Keys = append(a.account.Keys, key)
+			a.signers = append(a.signers, signer)
+		}
+
+		accounts[i] = a
+	}
+
+	return accounts
+}
+
+func lookupAccounts(accounts ...*signingAccount) flow.AccountLookup {
+	return func(address flow.Address) (*flow.Account, error) {
+		for _, a := range accounts {
+			if a.account.Address == address {
+				return a.account, nil
+			}
+		}
+		return nil, errors.New("account not found")
+	}
+}
+
+func TestTransaction_VerifySignatures(t *testing.T) {
+	newTransaction := func() (*flow.Transaction, *signingAccount, *signingAccount) {
+		accounts := newSigningAccounts([]int{500, 500}, []int{1000})
+		authorizer, payer := accounts[0], accounts[1]
+
+		tx := flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(authorizer.account.Address, 0, 0).
+			SetPayer(payer.account.Address).
+			AddAuthorizer(authorizer.account.Address)
+
+		return tx, authorizer, payer
+	}
+
+	sign := func(t *testing.T, tx *flow.Transaction, authorizer *signingAccount, payer *signingAccount) {
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 1, authorizer.signers[1]))
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		sign(t, tx, authorizer, payer)
+
+		assert.NoError(t, tx.VerifySignatures(lookupAccounts(authorizer, payer)))
+	})
+
+	t.Run("Payload signatures before envelope", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 1, authorizer.signers[1]))
+
+		lookup := lookupAccounts(authorizer, payer)
+		assert.NoError(t, tx.VerifyPayloadSignatures(lookup))
+		assert.ErrorIs(t, tx.VerifySignatures(lookup), flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+
+		err := tx.VerifySignatures(lookupAccounts(authorizer, payer))
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Missing proposal key signature", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		tx.SetProposalKey(authorizer.account.Address, 1, 0)
+		authorizer.account.Keys[0].Weight = 1000
+
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+
+		err := tx.VerifySignatures(lookupAccounts(authorizer, payer))
+		assert.ErrorIs(t, err, flow.ErrMissingProposalKeySignature)
+	})
+
+	t.Run("Duplicate signature", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+
+		lookup := lookupAccounts(authorizer, payer)
+		assert.ErrorIs(t, tx.VerifyPayloadSignatures(lookup), flow.ErrInvalidSignature)
+
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+		assert.ErrorIs(t, tx.VerifySignatures(lookup), flow.ErrInvalidSignature)
+	})
+
+	t.Run("Tampered payload", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		sign(t, tx, authorizer, payer)
+
+		tx.SetGasLimit(1)
+
+		err := tx.VerifySignatures(lookupAccounts(authorizer, payer))
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+	})
+
+	t.Run("Wrong key", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[1]))
+
+		err := tx.VerifyPayloadSignatures(lookupAccounts(authorizer, payer))
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		sign(t, tx, authorizer, payer)
+
+		authorizer.account.Keys[1].Revoked = true
+
+		err := tx.VerifySignatures(lookupAccounts(authorizer, payer))
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+	})
+
+	t.Run("Payer is also proposer and authorizer", func(t *testing.T) {
+		accounts := newSigningAccounts([]int{1000})
+		payer := accounts[0]
+
+		tx := flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetProposalKey(payer.account.Address, 0, 0).
+			SetPayer(payer.account.Address).
+			AddAuthorizer(payer.account.Address)
+
+		lookup := lookupAccounts(payer)
+		assert.NoError(t, tx.VerifyPayloadSignatures(lookup))
+
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+		assert.NoError(t, tx.VerifySignatures(lookup))
+	})
+
+	t.Run("Lookup failure", func(t *testing.T) {
+		tx, authorizer, payer := newTransaction()
+		sign(t, tx, authorizer, payer)
+
+		assert.Error(t, tx.VerifySignatures(lookupAccounts(payer)))
+	})
+}